@@ -3,19 +3,21 @@ package main
 import (
 	"api-gateway/internal/config"
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
 
 	"api-gateway/internal/handler"
 	"api-gateway/internal/middleware"
+	"pkg/app"
 	"pkg/common_handler"
+	pkgconfig "pkg/config"
 	"pkg/logger"
+	"pkg/metrics"
 	commonMiddleware "pkg/middleware"
+	"pkg/openapi"
+	"pkg/router"
+	"pkg/telemetry"
 )
 
 const (
@@ -24,52 +26,146 @@ const (
 )
 
 func main() {
-	cfg := config.LoadConfig()
-	log := logger.New("api-gateway", cfg.Log.Level)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	log := logger.New("api-gateway", cfg.Log.Level, cfg.Log.Format)
+	log.Info("Loaded configuration", "config", cfg.Redacted())
+
+	// Select error response format
+	errorFormat, err := common_handler.ParseErrorFormat(cfg.Server.ErrorFormat)
+	if err != nil {
+		log.Error("Invalid error format", "error", err)
+		os.Exit(1)
+	}
+	common_handler.SetErrorFormat(errorFormat)
+
+	// Setup tracing
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.Config{
+		ServiceName:    serviceName,
+		ServiceVersion: version,
+		Exporter:       cfg.Telemetry.Exporter,
+		OTLPEndpoint:   cfg.Telemetry.OTLPEndpoint,
+		OTLPInsecure:   cfg.Telemetry.OTLPInsecure,
+		SampleRatio:    cfg.Telemetry.SampleRatio,
+	})
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	application := app.New(cfg.Server.AppConfig(), log)
+	application.OnStop("tracing", shutdownTracing)
+
+	// Setup metrics
+	registry := metrics.NewRegistry()
+	httpMetrics := metrics.NewHTTPMetrics(registry)
 
 	healthHandler := common_handler.NewHealthHandler(serviceName, version)
-	gatewayHandler, err := handler.NewGatewayHandler(cfg.Services.AuthServiceURL, cfg.Services.BusinessServiceURL)
+	metricsHandler := common_handler.NewMetricsHandler(registry)
+	healthHandler.AddCheck("auth-service", common_handler.HTTPCheck(http.DefaultClient, cfg.Services.AuthServiceURL+healthHandler.LivePath()))
+	healthHandler.AddCheck("business-service", common_handler.HTTPCheck(http.DefaultClient, cfg.Services.BusinessServiceURL+healthHandler.LivePath()))
+	healthHandler.AddCheck("order-service", common_handler.HTTPCheck(http.DefaultClient, cfg.Services.OrderServiceURL+healthHandler.LivePath()))
+	routes, err := handler.BuildRoutes([]handler.Upstream{
+		{Service: "auth", URL: cfg.Services.AuthServiceURL},
+		{Service: "business", URL: cfg.Services.BusinessServiceURL},
+		{Service: "order", URL: cfg.Services.OrderServiceURL},
+	}, cfg.Services.VersionRoutes)
+	if err != nil {
+		log.Error("Invalid upstream routes", "error", err)
+		os.Exit(1)
+	}
+	gatewayHandler := handler.NewGatewayHandler(registry)
+	documents := handler.NewDocumentStore()
+	docsHandler := handler.NewDocsHandler(serviceName, version, routes, documents, log)
+
+	validationMode, err := middleware.ParseValidationMode(cfg.Validation.Mode)
 	if err != nil {
-		log.Error("Failed to create gateway common_handler: %v", err)
-		return
+		log.Error("Invalid OpenAPI validation mode", "error", err)
+		os.Exit(1)
+	}
+	validateResponses := cfg.Validation.Responses
+	if validateResponses && pkgconfig.IsProduction() {
+		log.Warn("Response validation is disabled in production")
+		validateResponses = false
 	}
 
 	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.JWKSURL)
+	corsMiddleware, err := middleware.NewCORSMiddleware(middleware.CORSOptions{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		ExposedHeaders:   cfg.CORS.ExposedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	})
+	if err != nil {
+		log.Error("Invalid CORS configuration", "error", err)
+		os.Exit(1)
+	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc(healthHandler.Path(), healthHandler.Health)
-	mux.Handle("/", authMiddleware.Authenticate(gatewayHandler))
-
-	muxHandler := commonMiddleware.LoggingMiddleware(log)(mux)
-	addr := fmt.Sprintf(":%d", cfg.Server.Port)
-	server := &http.Server{
-		Addr:    addr,
-		Handler: muxHandler,
-	}
-
-	// Start server in a goroutine
-	go func() {
-		log.Info("%s (SQLC + PGX) starting on %s", serviceName, addr)
-		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Error("Server failed to start: %v", err)
+	mux.HandleFunc(healthHandler.LivePath(), healthHandler.Live)
+	mux.HandleFunc(healthHandler.ReadyPath(), healthHandler.Ready)
+	mux.HandleFunc(metricsHandler.Path(), metricsHandler.Metrics)
+	mux.HandleFunc("GET "+docsHandler.OpenAPIPath(), docsHandler.OpenAPI)
+	mux.HandleFunc("GET "+docsHandler.UIPath(), docsHandler.UI)
+	for _, route := range routes {
+		proxy := gatewayHandler.Proxy(route)
+		proxy = middleware.NewSchemaValidator(validationMode, validateResponses, func(ctx context.Context) (*openapi.Document, error) {
+			return documents.Document(ctx, route)
+		}, log).Handle(proxy)
+		mux.Handle(route.Pattern(), authMiddleware.Authenticate(proxy))
+		// Login and registration are how clients obtain a token in the first place
+		publicPatterns, err := route.PublicPatterns(cfg.Auth.PublicRoutes)
+		if err != nil {
+			log.Error("Invalid public routes", "error", err)
 			os.Exit(1)
 		}
-	}()
-
-	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Info("Shutting down server...")
-
-	// Give outstanding requests a deadline for completion
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+		for _, pattern := range publicPatterns {
+			mux.Handle(pattern, authMiddleware.Anonymous(proxy))
+		}
+		log.Info("Routing to upstream", "prefix", route.Pattern(), "upstream", route.Upstream.String())
+	}
+	mux.HandleFunc("/", gatewayHandler.NotFound)
 
-	if err := server.Shutdown(ctx); err != nil {
-		log.Error("Server forced to shutdown: %v", err)
+	trustedProxies, err := commonMiddleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Error("Invalid trusted proxies", "error", err)
 		os.Exit(1)
 	}
+	muxHandler := commonMiddleware.Chain(
+		commonMiddleware.RequestIDMiddleware,
+		commonMiddleware.RecoveryMiddleware(log),
+		commonMiddleware.RealIPMiddleware(trustedProxies),
+		commonMiddleware.SecurityHeadersMiddleware,
+		telemetry.Middleware(serviceName),
+		commonMiddleware.LoggingMiddleware(log, cfg.Log.AccessLog()),
+		// Preflights are answered here, before they can reach authentication
+		corsMiddleware.Handle,
+		commonMiddleware.CompressionMiddleware,
+		// Exports stream for as long as the business service allows, past the request and write timeouts
+		commonMiddleware.TimeoutMiddleware(cfg.Server.RequestTimeout, commonMiddleware.TimeoutOverride{
+			Pattern: "GET /{version}/business/products/export",
+			Timeout: cfg.Server.ExportTimeout,
+		}),
+		// Import files may be larger than other bodies; the business service checks them against its own IMPORT_MAX_BYTES
+		commonMiddleware.MaxBodySizeMiddleware(cfg.Server.MaxBodyBytes, commonMiddleware.BodyLimitOverride{
+			Pattern:  "POST /{version}/business/products/imports",
+			MaxBytes: cfg.Server.ImportMaxBytes,
+		}),
+		commonMiddleware.MetricsMiddleware(httpMetrics),
+		router.RecordRoute,
+	)(mux)
 
-	log.Info("Server exited")
+	// Run server
+	application.Handle(muxHandler)
+	application.OnShutdown(healthHandler.MarkShuttingDown)
+	if err := application.Run(); err != nil {
+		log.Error("Server stopped with error", "error", err)
+		os.Exit(1)
+	}
 }
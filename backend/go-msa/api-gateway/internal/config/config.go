@@ -1,49 +1,124 @@
 package config
 
 import (
+	"net"
+	"strconv"
+	"time"
+
+	"pkg/app"
 	"pkg/config"
+	"pkg/middleware"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Services ServicesConfig
-	JWT      JWTConfig
-	Log      LogConfig
+	Server     ServerConfig     `yaml:"server"`
+	Services   ServicesConfig   `yaml:"services"`
+	JWT        JWTConfig        `yaml:"jwt"`
+	Auth       AuthConfig       `yaml:"auth"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Validation ValidationConfig `yaml:"validation"`
+	Telemetry  TelemetryConfig  `yaml:"telemetry"`
+	Log        LogConfig        `yaml:"log"`
 }
 
 type ServerConfig struct {
-	Port int
-	Host string
+	Port              int           `yaml:"port" env:"PORT" default:"8080"`
+	Host              string        `yaml:"host" env:"HOST" default:"0.0.0.0"`
+	ErrorFormat       string        `yaml:"error_format" env:"ERROR_FORMAT" default:"json"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT" default:"5s"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT" default:"15s"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT" default:"30s"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" default:"60s"`
+	DrainPeriod       time.Duration `yaml:"drain_period" env:"SERVER_DRAIN_PERIOD" default:"0s"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" default:"10s"`
+	RequestTimeout    time.Duration `yaml:"request_timeout" env:"SERVER_REQUEST_TIMEOUT" default:"30s"`
+	ExportTimeout     time.Duration `yaml:"export_timeout" env:"EXPORT_TIMEOUT" default:"10m"`
+	MaxBodyBytes      int64         `yaml:"max_body_bytes" env:"SERVER_MAX_BODY_BYTES" default:"10485760"`
+	ImportMaxBytes    int64         `yaml:"import_max_bytes" env:"IMPORT_MAX_BYTES" default:"10485760"`
+	TrustedProxies    []string      `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
 }
 
 type ServicesConfig struct {
-	AuthServiceURL     string
-	BusinessServiceURL string
+	AuthServiceURL     string `yaml:"auth_service_url" env:"AUTH_SERVICE_URL" default:"http://localhost:8081"`
+	BusinessServiceURL string `yaml:"business_service_url" env:"BUSINESS_SERVICE_URL" default:"http://localhost:8082"`
+	OrderServiceURL    string `yaml:"order_service_url" env:"ORDER_SERVICE_URL" default:"http://localhost:8083"`
+	// VersionRoutes override or add upstreams per API version during migrations, e.g. "v2/business=http://business-v2:8082"
+	VersionRoutes []string `yaml:"version_routes" env:"GATEWAY_VERSION_ROUTES"`
 }
 
 type JWTConfig struct {
-	JWKSURL string
+	JWKSURL string `yaml:"jwks_url" env:"JWKS_URL" default:"http://localhost:8081/jwks"`
+}
+
+// AuthConfig lists the routes served without a token, as "{method} {service}/{path}" relative to each version,
+// e.g. "POST auth/login". Every other route requires authentication.
+type AuthConfig struct {
+	PublicRoutes []string `yaml:"public_routes" env:"AUTH_PUBLIC_ROUTES" default:"POST auth/login,POST auth/register"`
+}
+
+// CORSConfig lists the browser origins allowed to call the gateway; CORS is disabled while AllowedOrigins is empty
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS" default:"GET,POST,PUT,PATCH,DELETE"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS" default:"Authorization,Content-Type,Accept,X-Request-ID"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS" default:"X-Request-ID"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" default:"false"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE" default:"10m"`
+}
+
+// ValidationConfig controls checking proxied traffic against the OpenAPI document of each upstream
+type ValidationConfig struct {
+	// Mode is off, report (log violations) or enforce (reject with 400)
+	Mode string `yaml:"mode" env:"OPENAPI_VALIDATION" default:"report"`
+	// Responses also checks upstream responses; ignored in production
+	Responses bool `yaml:"responses" env:"OPENAPI_VALIDATE_RESPONSES" default:"false"`
+}
+
+type TelemetryConfig struct {
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"OTLP_ENDPOINT"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env:"OTLP_INSECURE" default:"true"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1.0"`
 }
 
 type LogConfig struct {
-	Level string
-}
-
-func LoadConfig() *Config {
-	return &Config{
-		Server: ServerConfig{
-			Port: config.GetEnvAsInt("PORT", 8080),
-			Host: config.GetEnv("HOST", "0.0.0.0"),
-		},
-		Services: ServicesConfig{
-			AuthServiceURL:     config.GetEnv("AUTH_SERVICE_URL", "http://localhost:8081"),
-			BusinessServiceURL: config.GetEnv("BUSINESS_SERVICE_URL", "http://localhost:8082"),
-		},
-		JWT: JWTConfig{
-			JWKSURL: config.GetEnv("JWKS_URL", "http://localhost:8081/jwks"),
-		},
-		Log: LogConfig{
-			Level: config.GetEnv("LOG_LEVEL", "info"),
-		},
+	Level            string   `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Format           string   `yaml:"format" env:"LOG_FORMAT" default:"json"`
+	AccessSkipPaths  []string `yaml:"access_skip_paths" env:"ACCESS_LOG_SKIP_PATHS" default:"/health,/metrics"`
+	AccessSampleRate float64  `yaml:"access_sample_rate" env:"ACCESS_LOG_SAMPLE_RATE" default:"1"`
+}
+
+// AccessLog returns the access log settings for middleware.LoggingMiddleware
+func (c LogConfig) AccessLog() middleware.AccessLogConfig {
+	return middleware.AccessLogConfig{
+		SkipPaths:  c.AccessSkipPaths,
+		SampleRate: c.AccessSampleRate,
 	}
 }
+
+// AppConfig returns the HTTP server and shutdown settings for pkg/app
+func (c ServerConfig) AppConfig() app.Config {
+	return app.Config{
+		Addr:              net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		ReadTimeout:       c.ReadTimeout,
+		WriteTimeout:      c.WriteTimeout,
+		IdleTimeout:       c.IdleTimeout,
+		DrainPeriod:       c.DrainPeriod,
+		ShutdownTimeout:   c.ShutdownTimeout,
+	}
+}
+
+// LoadConfig loads configuration from defaults, an optional YAML file, environment variables and flags
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := config.Load(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Redacted returns the effective configuration with secrets masked, for logging at startup
+func (c *Config) Redacted() map[string]interface{} {
+	return config.Redacted(c)
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"pkg/common_handler"
+	"pkg/logger"
+	"pkg/openapi"
+)
+
+// docsCacheTTL bounds how stale a document can be after an upstream deploys
+const docsCacheTTL = time.Minute
+
+// docsRetryInterval spaces out fetches from an upstream whose document could not be loaded
+const docsRetryInterval = 10 * time.Second
+
+// DocumentStore fetches and caches the OpenAPI document each upstream publishes
+type DocumentStore struct {
+	client *http.Client
+
+	mu      sync.Mutex
+	entries map[string]*storedDocument
+}
+
+// errDocumentPending is returned while the first fetch of a document is still running
+var errDocumentPending = errors.New("document is still being fetched")
+
+// storedDocument is one upstream's cached document; its lock is never held during a fetch
+type storedDocument struct {
+	mu         sync.Mutex
+	doc        *openapi.Document
+	err        error
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+func NewDocumentStore() *DocumentStore {
+	return &DocumentStore{
+		client:  &http.Client{Timeout: 5 * time.Second},
+		entries: make(map[string]*storedDocument),
+	}
+}
+
+// Document returns the cached document of route. Once the cache expires the stale document is returned while
+// a fresh one is fetched in the background, and a failed refetch keeps serving the stale document rather than
+// none at all. Only the first fetch of a document makes a caller wait; concurrent callers get errDocumentPending.
+func (s *DocumentStore) Document(ctx context.Context, route Route) (*openapi.Document, error) {
+	s.mu.Lock()
+	entry, ok := s.entries[route.Pattern()]
+	if !ok {
+		entry = &storedDocument{}
+		s.entries[route.Pattern()] = entry
+	}
+	s.mu.Unlock()
+
+	entry.mu.Lock()
+	ttl := docsCacheTTL
+	if entry.doc == nil {
+		ttl = docsRetryInterval
+	}
+	if entry.refreshing || !entry.fetchedAt.IsZero() && time.Since(entry.fetchedAt) < ttl {
+		doc, err := entry.doc, entry.err
+		entry.mu.Unlock()
+		if doc == nil && err == nil {
+			err = errDocumentPending
+		}
+		return doc, err
+	}
+	entry.refreshing = true
+	stale := entry.doc
+	entry.mu.Unlock()
+
+	if stale != nil {
+		go s.refresh(context.WithoutCancel(ctx), route, entry)
+		return stale, nil
+	}
+
+	s.refresh(ctx, route, entry)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	return entry.doc, entry.err
+}
+
+// refresh fetches the document of route into entry
+func (s *DocumentStore) refresh(ctx context.Context, route Route, entry *storedDocument) {
+	doc, err := FetchDocument(ctx, s.client, route)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	entry.refreshing = false
+	entry.fetchedAt = time.Now()
+	if err != nil {
+		if entry.doc == nil {
+			entry.err = err
+		}
+		return
+	}
+	entry.doc, entry.err = doc, nil
+}
+
+// DocsHandler serves the OpenAPI documents of all upstreams merged into one, and Swagger UI on top of it
+type DocsHandler struct {
+	title   string
+	version string
+	routes  []Route
+	store   *DocumentStore
+	log     logger.Logger
+}
+
+func NewDocsHandler(title, version string, routes []Route, store *DocumentStore, log logger.Logger) *DocsHandler {
+	return &DocsHandler{
+		title:   title,
+		version: version,
+		routes:  routes,
+		store:   store,
+		log:     log,
+	}
+}
+
+// OpenAPIPath returns the path of the merged document
+func (d *DocsHandler) OpenAPIPath() string {
+	return openapi.Path
+}
+
+// UIPath returns the path of Swagger UI
+func (d *DocsHandler) UIPath() string {
+	return "/docs"
+}
+
+// OpenAPI handles GET /openapi.json
+func (d *DocsHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	doc, err := d.document(r.Context())
+	if err != nil {
+		d.log.ErrorContext(r.Context(), "Failed to merge OpenAPI documents", "error", err)
+		common_handler.WriteError(w, r, http.StatusBadGateway, "BAD_GATEWAY", "API documentation unavailable")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(doc)
+}
+
+// UI handles GET /docs
+func (d *DocsHandler) UI(w http.ResponseWriter, r *http.Request) {
+	// Swagger UI needs scripts and styles that the API-wide Content-Security-Policy forbids
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; script-src https://unpkg.com 'unsafe-inline'; "+
+		"style-src https://unpkg.com 'unsafe-inline'; img-src 'self' data: https://unpkg.com; connect-src 'self'")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, swaggerUIPage, d.title, d.OpenAPIPath())
+}
+
+// document merges the current documents of all routes; upstreams that are unavailable are left out
+func (d *DocsHandler) document(ctx context.Context) (*openapi.Document, error) {
+	sources := make([]openapi.Source, 0, len(d.routes))
+	for _, route := range d.routes {
+		doc, err := d.store.Document(ctx, route)
+		if err != nil {
+			d.log.WarnContext(ctx, "Skipping upstream OpenAPI document", "upstream", route.Service, "version", route.Version, "error", err)
+			continue
+		}
+		sources = append(sources, openapi.Source{
+			Name:     route.Service + "_" + route.Version,
+			Prefix:   strings.TrimSuffix(route.Pattern(), "/"),
+			Document: doc,
+		})
+	}
+
+	merged, err := openapi.Merge(openapi.Info{Title: d.title, Version: d.version}, sources)
+	if err != nil {
+		return nil, err
+	}
+	merged.Servers = []openapi.Server{{URL: "/"}}
+	return merged, nil
+}
+
+// FetchDocument downloads the OpenAPI document an upstream publishes
+func FetchDocument(ctx context.Context, client *http.Client, route Route) (*openapi.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, route.Upstream.JoinPath(openapi.Path).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var doc openapi.Document
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+	return &doc, nil
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>%s</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"pkg/openapi"
+)
+
+func TestDocumentStoreServesStaleDocumentWhileRefreshing(t *testing.T) {
+	var version atomic.Int32
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := version.Add(1)
+		if n > 1 {
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(openapi.Document{Info: openapi.Info{Version: strconv.Itoa(int(n))}})
+	}))
+	defer upstream.Close()
+	defer close(release)
+	upstreamURL, err := url.Parse(upstream.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	route := Route{Version: "v1", Service: "business", Upstream: upstreamURL}
+	store := NewDocumentStore()
+	ctx := context.Background()
+
+	first, err := store.Document(ctx, route)
+	if err != nil || first.Info.Version != "1" {
+		t.Fatalf("Document() = %+v, %v", first, err)
+	}
+
+	// Expire the cache; the refetch blocks until release is closed
+	store.entries[route.Pattern()].fetchedAt = time.Now().Add(-2 * docsCacheTTL)
+	for i := 0; i < 3; i++ {
+		done := make(chan *openapi.Document, 1)
+		go func() {
+			doc, _ := store.Document(ctx, route)
+			done <- doc
+		}()
+		select {
+		case doc := <-done:
+			if doc != first {
+				t.Errorf("Document() = %+v during a refresh, want the stale document", doc)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Document() waited for the refresh")
+		}
+	}
+	for deadline := time.Now().Add(time.Second); version.Load() < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	if calls := version.Load(); calls != 2 {
+		t.Errorf("upstream fetched %d times, want one refresh at a time", calls)
+	}
+}
+
+func TestDocumentStoreFirstFetchFailure(t *testing.T) {
+	upstream := httptest.NewServer(http.NotFoundHandler())
+	defer upstream.Close()
+	upstreamURL, err := url.Parse(upstream.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	route := Route{Version: "v1", Service: "business", Upstream: upstreamURL}
+	store := NewDocumentStore()
+
+	if doc, err := store.Document(context.Background(), route); err == nil || doc != nil {
+		t.Errorf("Document() = %v, %v, want the fetch error", doc, err)
+	}
+	// The failure is cached until the retry interval passes
+	upstream.Close()
+	if _, err := store.Document(context.Background(), route); err == nil || err.Error() != "unexpected status 404" {
+		t.Errorf("Document() error = %v, want the cached failure", err)
+	}
+}
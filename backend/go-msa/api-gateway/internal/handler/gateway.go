@@ -1,47 +1,162 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"pkg/common_handler"
+	"pkg/logger"
+	"pkg/middleware"
+	"pkg/router"
+	"pkg/telemetry"
 )
 
-type GatewayHandler struct {
-	authServiceURL     *url.URL
-	businessServiceURL *url.URL
+// Route maps the public /{version}/{service} prefix to the upstream serving that version
+type Route struct {
+	Version  string
+	Service  string
+	Upstream *url.URL
 }
 
-func NewGatewayHandler(authServiceURL, businessServiceURL string) (*GatewayHandler, error) {
-	authURL, err := url.Parse(authServiceURL)
+// NewRoute parses the upstream URL of a route
+func NewRoute(version, service, upstream string) (Route, error) {
+	u, err := url.Parse(upstream)
 	if err != nil {
-		return nil, err
+		return Route{}, fmt.Errorf("invalid upstream for %s/%s: %w", version, service, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return Route{}, fmt.Errorf("invalid upstream for %s/%s: %q is not an absolute URL", version, service, upstream)
+	}
+	return Route{Version: version, Service: service, Upstream: u}, nil
+}
+
+// ParseRoute parses a "{version}/{service}={upstream}" override, e.g. "v2/business=http://business-v2:8082"
+func ParseRoute(spec string) (Route, error) {
+	prefix, upstream, ok := strings.Cut(spec, "=")
+	version, service, ok2 := strings.Cut(strings.Trim(prefix, "/"), "/")
+	if !ok || !ok2 || version == "" || service == "" || strings.Contains(service, "/") {
+		return Route{}, fmt.Errorf("invalid route %q: expected {version}/{service}={upstream}", spec)
 	}
+	return NewRoute(version, service, upstream)
+}
 
-	businessURL, err := url.Parse(businessServiceURL)
-	if err != nil {
-		return nil, err
+// Upstream is the v1 URL of a service
+type Upstream struct {
+	Service string
+	URL     string
+}
+
+// BuildRoutes returns the v1 route of each upstream followed by the overrides, "{version}/{service}={upstream}" specs
+// that replace or add the upstream of one version during migrations
+func BuildRoutes(upstreams []Upstream, overrides []string) ([]Route, error) {
+	routes := make([]Route, 0, len(upstreams)+len(overrides))
+	for _, upstream := range upstreams {
+		route, err := NewRoute("v1", upstream.Service, upstream.URL)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+
+	for _, spec := range overrides {
+		route, err := ParseRoute(spec)
+		if err != nil {
+			return nil, err
+		}
+		// A later entry for the same prefix replaces the earlier one
+		replaced := false
+		for i := range routes {
+			if routes[i].Version == route.Version && routes[i].Service == route.Service {
+				routes[i] = route
+				replaced = true
+			}
+		}
+		if !replaced {
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+// Pattern returns the ServeMux pattern matching every path under the route prefix
+func (rt Route) Pattern() string {
+	return router.NewPathBuilder(rt.Version, rt.Service).BasePath() + "/"
+}
+
+// PublicPatterns returns the ServeMux patterns of the public routes, "{method} {service}/{path}" specs such as
+// "POST auth/login", that fall under this route. They are more specific than Pattern, so they take precedence.
+func (rt Route) PublicPatterns(specs []string) ([]string, error) {
+	var patterns []string
+	for _, spec := range specs {
+		method, path, ok := strings.Cut(strings.TrimSpace(spec), " ")
+		service, rest, ok2 := strings.Cut(strings.Trim(strings.TrimSpace(path), "/"), "/")
+		if !ok || !ok2 || method == "" || rest == "" {
+			return nil, fmt.Errorf("invalid public route %q: expected {method} {service}/{path}", spec)
+		}
+		if service == rt.Service {
+			patterns = append(patterns, method+" "+router.NewPathBuilder(rt.Version, rt.Service).BasePath()+"/"+rest)
+		}
 	}
+	return patterns, nil
+}
+
+type GatewayHandler struct {
+	transport http.RoundTripper
+	metrics   *upstreamMetrics
+}
 
+func NewGatewayHandler(reg prometheus.Registerer) *GatewayHandler {
 	return &GatewayHandler{
-		authServiceURL:     authURL,
-		businessServiceURL: businessURL,
-	}, nil
+		transport: telemetry.Transport(http.DefaultTransport),
+		metrics:   newUpstreamMetrics(reg),
+	}
 }
 
-func (g *GatewayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	var proxy *httputil.ReverseProxy
-	path := r.URL.Path
+// Proxy forwards requests under the route prefix unchanged, so each upstream sees its own versioned paths
+func (g *GatewayHandler) Proxy(route Route) http.Handler {
+	return g.newProxy(route)
+}
+
+// NotFound answers paths that match no route
+func (g *GatewayHandler) NotFound(w http.ResponseWriter, r *http.Request) {
+	common_handler.WriteError(w, r, http.StatusNotFound, "NOT_FOUND", "Not Found")
+}
+
+// newProxy creates a reverse proxy that forwards the request ID and trace context upstream
+func (g *GatewayHandler) newProxy(route Route) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(route.Upstream)
+	proxy.Transport = &upstreamTransport{upstream: route.Service, version: route.Version, next: g.transport, metrics: g.metrics}
+
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		if requestID := logger.RequestIDFromContext(req.Context()); requestID != "" {
+			req.Header.Set(middleware.RequestIDHeader, requestID)
+		}
+	}
+
+	// The gateway already echoed the request ID and set its own security headers, and it owns the CORS policy,
+	// so drop upstream copies instead of duplicating them
+	proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.Header.Del(middleware.RequestIDHeader)
+		for _, name := range middleware.SecurityHeaders {
+			resp.Header.Del(name)
+		}
+		for name := range resp.Header {
+			if strings.HasPrefix(name, "Access-Control-") {
+				resp.Header.Del(name)
+			}
+		}
+		return nil
+	}
 
-	switch {
-	case strings.HasPrefix(path, "/auth"):
-		proxy = httputil.NewSingleHostReverseProxy(g.authServiceURL)
-	case strings.HasPrefix(path, "/products"):
-		proxy = httputil.NewSingleHostReverseProxy(g.businessServiceURL)
-	default:
-		http.Error(w, "Not Found", http.StatusNotFound)
-		return
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		common_handler.WriteError(w, r, http.StatusBadGateway, "BAD_GATEWAY", "Upstream service unavailable")
 	}
 
-	proxy.ServeHTTP(w, r)
+	return proxy
 }
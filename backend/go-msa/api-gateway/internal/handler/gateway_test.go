@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"pkg/middleware"
+)
+
+func TestProxyDropsUpstreamCopiesOfGatewayHeaders(t *testing.T) {
+	upstream := httptest.NewServer(middleware.SecurityHeadersMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(middleware.RequestIDHeader, "upstream-id")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("X-Upstream", "kept")
+	})))
+	defer upstream.Close()
+	upstreamURL, err := url.Parse(upstream.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gateway := NewGatewayHandler(prometheus.NewRegistry())
+	proxy := middleware.SecurityHeadersMiddleware(gateway.Proxy(Route{Version: "v1", Service: "business", Upstream: upstreamURL}))
+	w := httptest.NewRecorder()
+
+	proxy.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/business/products", nil))
+
+	for _, name := range middleware.SecurityHeaders {
+		if values := w.Header().Values(name); len(values) > 1 {
+			t.Errorf("%s sent %d times", name, len(values))
+		}
+	}
+	if values := w.Header().Values("X-Content-Type-Options"); len(values) != 1 {
+		t.Errorf("X-Content-Type-Options = %v, want the gateway's value once", values)
+	}
+	if w.Header().Get(middleware.RequestIDHeader) != "" || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("upstream request ID or CORS headers were passed through")
+	}
+	if w.Header().Get("X-Upstream") != "kept" {
+		t.Error("upstream header was dropped")
+	}
+}
+
+func TestBuildRoutes(t *testing.T) {
+	routes, err := BuildRoutes([]Upstream{
+		{Service: "auth", URL: "http://auth:8081"},
+		{Service: "business", URL: "http://business:8082"},
+	}, []string{"v1/business=http://business-next:8082", "v2/business=http://business-v2:8082"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, route := range routes {
+		got = append(got, route.Pattern()+"="+route.Upstream.String())
+	}
+	want := []string{"/v1/auth/=http://auth:8081", "/v1/business/=http://business-next:8082", "/v2/business/=http://business-v2:8082"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("routes = %v, want %v", got, want)
+	}
+
+	if _, err := BuildRoutes(nil, []string{"business=http://business:8082"}); err == nil {
+		t.Error("an override without a version was accepted")
+	}
+	if _, err := BuildRoutes([]Upstream{{Service: "auth", URL: "auth:8081"}}, nil); err == nil {
+		t.Error("a relative upstream was accepted")
+	}
+}
+
+func TestPublicPatterns(t *testing.T) {
+	route := Route{Version: "v2", Service: "auth"}
+	specs := []string{"POST auth/login", "POST /auth/register", "GET business/products"}
+
+	got, err := route.PublicPatterns(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "POST /v2/auth/login POST /v2/auth/register"; strings.Join(got, " ") != want {
+		t.Errorf("PublicPatterns() = %v, want %s", got, want)
+	}
+
+	// A public route must name a path inside the service, never the whole upstream
+	for _, spec := range []string{"auth/login", "POST auth", "POST auth/"} {
+		if _, err := route.PublicPatterns([]string{spec}); err == nil {
+			t.Errorf("PublicPatterns(%q) accepted", spec)
+		}
+	}
+
+	// The public patterns must take precedence over the route without conflicting with it
+	mux := http.NewServeMux()
+	mux.Handle(route.Pattern(), http.NotFoundHandler())
+	for _, pattern := range got {
+		mux.Handle(pattern, http.NotFoundHandler())
+	}
+	for path, want := range map[string]string{"/v2/auth/login": "POST /v2/auth/login", "/v2/auth/me": "/v2/auth/"} {
+		if _, pattern := mux.Handler(httptest.NewRequest(http.MethodPost, path, nil)); pattern != want {
+			t.Errorf("POST %s matched %q, want %q", path, pattern, want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// upstreamMetrics tracks latency and failures per proxied service
+type upstreamMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+func newUpstreamMetrics(reg prometheus.Registerer) *upstreamMetrics {
+	m := &upstreamMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gateway_upstream_request_duration_seconds",
+			Help:    "Latency of requests proxied to an upstream service.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"upstream", "version", "status"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gateway_upstream_errors_total",
+			Help: "Proxied requests that failed in transport or returned a 5xx.",
+		}, []string{"upstream", "version", "reason"}),
+	}
+
+	reg.MustRegister(m.duration, m.errors)
+	return m
+}
+
+// upstreamTransport records upstreamMetrics around each proxied round trip
+type upstreamTransport struct {
+	upstream string
+	version  string
+	next     http.RoundTripper
+	metrics  *upstreamMetrics
+}
+
+func (t *upstreamTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Seconds()
+
+	if err != nil {
+		t.metrics.duration.WithLabelValues(t.upstream, t.version, "error").Observe(elapsed)
+		t.metrics.errors.WithLabelValues(t.upstream, t.version, "transport").Inc()
+		return nil, err
+	}
+
+	t.metrics.duration.WithLabelValues(t.upstream, t.version, strconv.Itoa(resp.StatusCode)).Observe(elapsed)
+	if resp.StatusCode >= 500 {
+		t.metrics.errors.WithLabelValues(t.upstream, t.version, "status_5xx").Inc()
+	}
+	return resp, nil
+}
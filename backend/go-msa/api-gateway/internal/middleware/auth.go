@@ -3,6 +3,9 @@ package middleware
 import (
 	"net/http"
 	"strings"
+
+	"pkg/common_handler"
+	"pkg/logger"
 )
 
 type AuthMiddleware struct {
@@ -20,7 +23,7 @@ func (a *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 		authHeader := r.Header.Get("Authorization")
 
 		if authHeader == "" {
-			http.Error(w, "Authorization header required", http.StatusUnauthorized)
+			common_handler.WriteError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "Authorization header required")
 			return
 		}
 
@@ -28,13 +31,22 @@ func (a *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 
 		userID, err := a.validateToken(token)
 		if err != nil {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			common_handler.WriteError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid token")
 			return
 		}
 
 		r.Header.Del("Authorization")
 		r.Header.Set("X-Authenticated-User-ID", userID)
+		r = r.WithContext(logger.ContextWithUserID(r.Context(), userID))
+
+		next.ServeHTTP(w, r)
+	})
+}
 
+// Anonymous serves a public route. Upstreams trust X-Authenticated-User-ID, so a client-supplied copy is dropped.
+func (a *AuthMiddleware) Anonymous(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del("X-Authenticated-User-ID")
 		next.ServeHTTP(w, r)
 	})
 }
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddlewareUserHeader(t *testing.T) {
+	auth := NewAuthMiddleware("")
+	var forwarded string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get("X-Authenticated-User-ID")
+	})
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		token   string
+		want    string
+	}{
+		{name: "public route drops a forged user", handler: auth.Anonymous(next), want: ""},
+		{name: "public route drops a forged user even with a token", handler: auth.Anonymous(next), token: "Bearer token", want: ""},
+		{name: "authenticated route replaces a forged user", handler: auth.Authenticate(next), token: "Bearer token", want: "user123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forwarded = "unset"
+			r := httptest.NewRequest(http.MethodPost, "/v1/auth/login", nil)
+			r.Header.Set("X-Authenticated-User-ID", "admin")
+			if tt.token != "" {
+				r.Header.Set("Authorization", tt.token)
+			}
+
+			tt.handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if forwarded != tt.want {
+				t.Errorf("X-Authenticated-User-ID = %q, want %q", forwarded, tt.want)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	auth.Authenticate(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/business/products", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status without a token = %d, want 401", w.Code)
+	}
+}
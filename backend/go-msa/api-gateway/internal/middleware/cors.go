@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures which browser origins may call the gateway
+type CORSOptions struct {
+	// AllowedOrigins are exact origins ("https://app.example.com"), wildcard subdomains
+	// ("https://*.example.com") or "*" for any origin. Empty disables CORS.
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+type CORSMiddleware struct {
+	anyOrigin        bool
+	origins          map[string]struct{}
+	wildcards        []wildcardOrigin
+	methods          map[string]struct{}
+	anyHeader        bool
+	headers          map[string]struct{}
+	allowMethods     string
+	allowHeaders     string
+	exposeHeaders    string
+	allowCredentials bool
+	maxAge           string
+}
+
+// wildcardOrigin matches any subdomain of host under the given scheme (and port, if set)
+type wildcardOrigin struct {
+	scheme string
+	suffix string
+	port   string
+}
+
+func NewCORSMiddleware(opts CORSOptions) (*CORSMiddleware, error) {
+	c := &CORSMiddleware{
+		origins:          make(map[string]struct{}),
+		methods:          make(map[string]struct{}),
+		headers:          make(map[string]struct{}),
+		allowCredentials: opts.AllowCredentials,
+	}
+
+	for _, origin := range opts.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		switch {
+		case origin == "":
+			continue
+		case origin == "*":
+			c.anyOrigin = true
+		case strings.Contains(origin, "*"):
+			w, err := parseWildcardOrigin(origin)
+			if err != nil {
+				return nil, err
+			}
+			c.wildcards = append(c.wildcards, w)
+		default:
+			c.origins[strings.TrimSuffix(origin, "/")] = struct{}{}
+		}
+	}
+	// Browsers refuse "Access-Control-Allow-Origin: *" on credentialed requests, and reflecting every origin instead
+	// would let any site act on behalf of a logged-in user
+	if c.anyOrigin && c.allowCredentials {
+		return nil, errors.New("cors: credentials cannot be allowed for any origin")
+	}
+
+	methods := make([]string, 0, len(opts.AllowedMethods))
+	for _, method := range opts.AllowedMethods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			continue
+		}
+		c.methods[method] = struct{}{}
+		methods = append(methods, method)
+	}
+	c.allowMethods = strings.Join(methods, ", ")
+
+	headers := make([]string, 0, len(opts.AllowedHeaders))
+	for _, header := range opts.AllowedHeaders {
+		header = strings.TrimSpace(header)
+		switch header {
+		case "":
+			continue
+		case "*":
+			c.anyHeader = true
+		default:
+			c.headers[strings.ToLower(header)] = struct{}{}
+			headers = append(headers, http.CanonicalHeaderKey(header))
+		}
+	}
+	c.allowHeaders = strings.Join(headers, ", ")
+	c.exposeHeaders = strings.Join(opts.ExposedHeaders, ", ")
+
+	if opts.MaxAge > 0 {
+		c.maxAge = strconv.Itoa(int(opts.MaxAge / time.Second))
+	}
+
+	return c, nil
+}
+
+func parseWildcardOrigin(origin string) (wildcardOrigin, error) {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme == "" || u.Path != "" && u.Path != "/" {
+		return wildcardOrigin{}, fmt.Errorf("cors: invalid origin %q", origin)
+	}
+	host := u.Hostname()
+	if !strings.HasPrefix(host, "*.") || strings.Contains(host[2:], "*") {
+		return wildcardOrigin{}, fmt.Errorf("cors: wildcard must be the leftmost label in %q", origin)
+	}
+	return wildcardOrigin{scheme: u.Scheme, suffix: host[1:], port: u.Port()}, nil
+}
+
+// Handle answers preflight requests itself, so it must run before authentication,
+// and adds CORS headers to the actual responses of allowed origins.
+// With no allowed origins, CORS is disabled and requests pass through untouched.
+func (c *CORSMiddleware) Handle(next http.Handler) http.Handler {
+	if !c.enabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if preflight {
+			h.Add("Vary", "Origin")
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+			c.preflight(w, r, origin)
+			return
+		}
+
+		if !c.anyOrigin {
+			h.Add("Vary", "Origin")
+		}
+		if c.allowOrigin(origin) {
+			c.setOrigin(h, origin)
+			if c.exposeHeaders != "" {
+				h.Set("Access-Control-Expose-Headers", c.exposeHeaders)
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// preflight responds 204 without CORS headers to anything disallowed, which makes the browser block the request
+func (c *CORSMiddleware) preflight(w http.ResponseWriter, r *http.Request, origin string) {
+	defer w.WriteHeader(http.StatusNoContent)
+
+	if !c.allowOrigin(origin) {
+		return
+	}
+
+	method := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+	if _, ok := c.methods[method]; !ok {
+		return
+	}
+
+	requested := r.Header.Get("Access-Control-Request-Headers")
+	if !c.allowRequestHeaders(requested) {
+		return
+	}
+
+	h := w.Header()
+	c.setOrigin(h, origin)
+	h.Set("Access-Control-Allow-Methods", c.allowMethods)
+	if c.anyHeader && requested != "" {
+		h.Set("Access-Control-Allow-Headers", requested)
+	} else if c.allowHeaders != "" {
+		h.Set("Access-Control-Allow-Headers", c.allowHeaders)
+	}
+	if c.maxAge != "" {
+		h.Set("Access-Control-Max-Age", c.maxAge)
+	}
+}
+
+// enabled reports whether any origin is allowed
+func (c *CORSMiddleware) enabled() bool {
+	return c.anyOrigin || len(c.origins) > 0 || len(c.wildcards) > 0
+}
+
+func (c *CORSMiddleware) setOrigin(h http.Header, origin string) {
+	if c.anyOrigin {
+		h.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	h.Set("Access-Control-Allow-Origin", origin)
+	if c.allowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c *CORSMiddleware) allowOrigin(origin string) bool {
+	if c.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if _, ok := c.origins[origin]; ok {
+		return true
+	}
+	if len(c.wildcards) == 0 {
+		return false
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	for _, w := range c.wildcards {
+		if u.Scheme == w.scheme && u.Port() == w.port && len(host) > len(w.suffix) && strings.HasSuffix(host, w.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CORSMiddleware) allowRequestHeaders(requested string) bool {
+	if c.anyHeader {
+		return true
+	}
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header == "" {
+			continue
+		}
+		if _, ok := c.headers[header]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestCORS(t *testing.T, opts CORSOptions) http.Handler {
+	t.Helper()
+	cors, err := NewCORSMiddleware(opts)
+	if err != nil {
+		t.Fatalf("NewCORSMiddleware() error = %v", err)
+	}
+	return cors.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+}
+
+func preflightRequest(origin, method, headers string) *http.Request {
+	r := httptest.NewRequest(http.MethodOptions, "/v1/business/products", nil)
+	r.Header.Set("Origin", origin)
+	r.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		r.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	return r
+}
+
+func TestCORSAllowOrigin(t *testing.T) {
+	handler := newTestCORS(t, CORSOptions{
+		AllowedOrigins: []string{"https://app.example.com/", "https://*.example.org", "http://*.local.test:3000"},
+		AllowedMethods: []string{"GET"},
+	})
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{origin: "https://app.example.com", want: true},
+		{origin: "HTTPS://APP.EXAMPLE.COM", want: true},
+		{origin: "http://app.example.com", want: false},
+		{origin: "https://admin.example.org", want: true},
+		{origin: "https://a.b.example.org", want: true},
+		{origin: "https://example.org", want: false},
+		{origin: "https://evilexample.org", want: false},
+		{origin: "http://web.local.test:3000", want: true},
+		{origin: "http://web.local.test", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Origin", tt.origin)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Access-Control-Allow-Origin") == tt.origin; got != tt.want {
+				t.Errorf("allowed = %v, want %v", got, tt.want)
+			}
+			if w.Code != http.StatusTeapot {
+				t.Errorf("status = %d, want the next handler to run", w.Code)
+			}
+		})
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	handler := newTestCORS(t, CORSOptions{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"authorization", "content-type"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+
+	tests := []struct {
+		name    string
+		request *http.Request
+		allowed bool
+	}{
+		{name: "allowed", request: preflightRequest("https://app.example.com", "POST", "Content-Type, Authorization"), allowed: true},
+		{name: "other origin", request: preflightRequest("https://evil.example.com", "POST", ""), allowed: false},
+		{name: "method not allowed", request: preflightRequest("https://app.example.com", "DELETE", ""), allowed: false},
+		{name: "header not allowed", request: preflightRequest("https://app.example.com", "POST", "X-Debug"), allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, tt.request)
+
+			if w.Code != http.StatusNoContent {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin") != ""; got != tt.allowed {
+				t.Errorf("allowed = %v, want %v", got, tt.allowed)
+			}
+			if tt.allowed {
+				if w.Header().Get("Access-Control-Allow-Credentials") != "true" || w.Header().Get("Access-Control-Max-Age") != "600" {
+					t.Errorf("headers = %v", w.Header())
+				}
+			}
+		})
+	}
+}
+
+func TestCORSDisabledPassesThrough(t *testing.T) {
+	handler := newTestCORS(t, CORSOptions{AllowedMethods: []string{"GET"}})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, preflightRequest("https://app.example.com", "GET", ""))
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want the OPTIONS request to reach the next handler", w.Code)
+	}
+	if len(w.Header()) != 0 {
+		t.Errorf("headers = %v, want none", w.Header())
+	}
+}
+
+func TestNewCORSMiddlewareValidation(t *testing.T) {
+	if _, err := NewCORSMiddleware(CORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true}); err == nil {
+		t.Error("credentials with any origin were accepted")
+	}
+	if _, err := NewCORSMiddleware(CORSOptions{AllowedOrigins: []string{"https://app.*.example.com"}}); err == nil {
+		t.Error("a wildcard that is not the leftmost label was accepted")
+	}
+}
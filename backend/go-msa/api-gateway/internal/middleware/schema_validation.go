@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"pkg/common_handler"
+	apperrors "pkg/errors"
+	"pkg/logger"
+	"pkg/models"
+	"pkg/openapi"
+)
+
+// ValidationMode selects what happens to requests that do not match the upstream's OpenAPI document
+type ValidationMode string
+
+const (
+	// ValidationOff skips schema validation
+	ValidationOff ValidationMode = "off"
+	// ValidationReport logs violations and proxies the request anyway
+	ValidationReport ValidationMode = "report"
+	// ValidationEnforce rejects violating requests with 400 VALIDATION_ERROR before they reach the upstream
+	ValidationEnforce ValidationMode = "enforce"
+)
+
+// maxCapturedResponse bounds how much of a response body is kept for response validation
+const maxCapturedResponse = 1 << 20
+
+// maxValidatedRequest bounds how much of a request body is read for validation; larger bodies are forwarded unchecked
+const maxValidatedRequest = 1 << 20
+
+// ParseValidationMode parses a validation mode name
+func ParseValidationMode(value string) (ValidationMode, error) {
+	switch mode := ValidationMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case ValidationOff, ValidationReport, ValidationEnforce:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown validation mode %q (want off, report or enforce)", value)
+	}
+}
+
+// DocumentLoader returns the current OpenAPI document of one upstream
+type DocumentLoader func(ctx context.Context) (*openapi.Document, error)
+
+// SchemaValidator checks requests proxied to one upstream against the document that upstream publishes.
+// While the document cannot be loaded requests pass through unvalidated.
+type SchemaValidator struct {
+	mode      ValidationMode
+	responses bool
+	load      DocumentLoader
+	log       logger.Logger
+
+	mu        sync.Mutex
+	doc       *openapi.Document
+	validator *openapi.Validator
+}
+
+// NewSchemaValidator creates a validator for one upstream.
+// Response validation only logs, since the response has already been sent by the time it is checked.
+func NewSchemaValidator(mode ValidationMode, responses bool, load DocumentLoader, log logger.Logger) *SchemaValidator {
+	return &SchemaValidator{
+		mode:      mode,
+		responses: responses,
+		load:      load,
+		log:       log,
+	}
+}
+
+func (s *SchemaValidator) Handle(next http.Handler) http.Handler {
+	if s.mode == ValidationOff && !s.responses {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		validator, err := s.current(ctx)
+		if err != nil {
+			s.log.DebugContext(ctx, "Skipping schema validation", "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		match, ok := validator.Find(r.Method, r.URL.Path)
+		if !ok {
+			// Unknown routes are the upstream's to answer, typically with 404 or 405
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if s.mode != ValidationOff {
+			if fields := s.validateRequest(r, validator, match); len(fields) > 0 {
+				if s.mode == ValidationEnforce {
+					common_handler.WriteAppError(w, r, apperrors.NewValidationError("Request does not match the API schema", fields))
+					return
+				}
+				s.log.WarnContext(ctx, "Request does not match the API schema", "route", match.Path, "errors", fields)
+			}
+		}
+
+		if !s.responses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &captureWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(cw, r)
+
+		// Compressed or truncated bodies cannot be checked
+		if cw.overflow || cw.Header().Get("Content-Encoding") != "" {
+			return
+		}
+		if fields := validator.ValidateResponse(match, cw.status, cw.Header().Get("Content-Type"), cw.body.Bytes()); len(fields) > 0 {
+			s.log.WarnContext(ctx, "Response does not match the API schema", "route", match.Path, "status", cw.status, "errors", fields)
+		}
+	})
+}
+
+// validateRequest reads the body for validation and puts it back for the proxy. Only bodies the document checks
+// as JSON are read, so uploads such as CSV imports stream to the upstream untouched. A body that is too large
+// for validation only has its parameters checked, and one that cannot be read is forwarded as is so the upstream
+// reports the failure.
+func (s *SchemaValidator) validateRequest(r *http.Request, validator *openapi.Validator, match openapi.Match) []models.FieldError {
+	contentType := r.Header.Get("Content-Type")
+	var body []byte
+	if match.ReadsBody(contentType) {
+		if r.Body == nil || r.Body == http.NoBody {
+			body = []byte{}
+		} else {
+			read, err := io.ReadAll(io.LimitReader(r.Body, maxValidatedRequest+1))
+			r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(read), r.Body), Closer: r.Body}
+			if err != nil {
+				return nil
+			}
+			if len(read) <= maxValidatedRequest {
+				body = read
+			}
+		}
+	}
+
+	return validator.ValidateRequest(match, r.URL.Query(), contentType, body)
+}
+
+// current returns the validator for the latest document, rebuilding it when the document changes
+func (s *SchemaValidator) current(ctx context.Context) (*openapi.Validator, error) {
+	doc, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if doc != s.doc {
+		s.doc = doc
+		s.validator = openapi.NewValidator(doc)
+	}
+	return s.validator, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// captureWriter keeps a copy of the response body for validation while passing it through
+type captureWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	overflow    bool
+}
+
+func (cw *captureWriter) WriteHeader(code int) {
+	if !cw.wroteHeader && code >= http.StatusOK {
+		cw.status = code
+		cw.wroteHeader = true
+	}
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *captureWriter) Write(p []byte) (int, error) {
+	cw.wroteHeader = true
+	if !cw.overflow {
+		if cw.body.Len()+len(p) > maxCapturedResponse {
+			cw.overflow = true
+			cw.body.Reset()
+		} else {
+			cw.body.Write(p)
+		}
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+func (cw *captureWriter) Flush() {
+	_ = http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+func (cw *captureWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pkg/logger"
+	"pkg/openapi"
+)
+
+type testAdjustRequest struct {
+	Delta int32 `json:"delta" validate:"required,min=-100,max=100"`
+}
+
+func newTestSchemaValidator(t *testing.T, mode ValidationMode, next http.Handler) http.Handler {
+	t.Helper()
+	spec := openapi.New("business", "v1")
+	spec.Add("POST /v1/business/products/{id}/stock/adjust", openapi.Endpoint{Request: testAdjustRequest{}})
+	spec.Add("POST /v1/business/products/imports", openapi.Endpoint{RequestMediaTypes: []string{"text/csv"}})
+	doc := spec.Document()
+
+	log := logger.NewWithWriters("api-gateway", "error", "json", io.Discard, io.Discard)
+	load := func(context.Context) (*openapi.Document, error) { return doc, nil }
+	return NewSchemaValidator(mode, false, load, log).Handle(next)
+}
+
+func TestSchemaValidatorModes(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       ValidationMode
+		body       string
+		wantStatus int
+	}{
+		{name: "enforce accepts a valid body", mode: ValidationEnforce, body: `{"delta":5}`, wantStatus: http.StatusTeapot},
+		{name: "enforce rejects an invalid body", mode: ValidationEnforce, body: `{"delta":500}`, wantStatus: http.StatusBadRequest},
+		{name: "report forwards an invalid body", mode: ValidationReport, body: `{"delta":500}`, wantStatus: http.StatusTeapot},
+		{name: "off forwards an invalid body", mode: ValidationOff, body: `{"delta":500}`, wantStatus: http.StatusTeapot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var forwarded string
+			handler := newTestSchemaValidator(t, tt.mode, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				forwarded = string(body)
+				w.WriteHeader(http.StatusTeapot)
+			}))
+			r := httptest.NewRequest(http.MethodPost, "/v1/business/products/1/stock/adjust", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusBadRequest && !strings.Contains(w.Body.String(), "delta") {
+				t.Errorf("body = %s, want the failing field", w.Body.String())
+			}
+			if tt.wantStatus == http.StatusTeapot && forwarded != tt.body {
+				t.Errorf("upstream got %q, want the body unchanged", forwarded)
+			}
+		})
+	}
+}
+
+// countingReader records how much of a body was read before the upstream saw it
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestSchemaValidatorBodyReads(t *testing.T) {
+	largeJSON := `{"delta":1,"padding":"` + strings.Repeat("x", maxValidatedRequest) + `"}`
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		wantRead    bool
+	}{
+		{name: "JSON is read for validation", path: "/v1/business/products/1/stock/adjust", contentType: "application/json", body: `{"delta":1}`, wantRead: true},
+		{name: "CSV uploads stream through", path: "/v1/business/products/imports", contentType: "text/csv", body: "external_sku,name,price,stock\n"},
+		{name: "JSON over the cap is forwarded unchecked", path: "/v1/business/products/1/stock/adjust", contentType: "application/json", body: largeJSON, wantRead: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &countingReader{Reader: strings.NewReader(tt.body)}
+			var readBefore int
+			var forwarded []byte
+			handler := newTestSchemaValidator(t, ValidationEnforce, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				readBefore = reader.n
+				forwarded, _ = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusTeapot)
+			}))
+			r := httptest.NewRequest(http.MethodPost, tt.path, io.NopCloser(reader))
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != http.StatusTeapot {
+				t.Fatalf("status = %d, want the request forwarded: %s", w.Code, w.Body.String())
+			}
+			if (readBefore > 0) != tt.wantRead || readBefore > maxValidatedRequest+1 {
+				t.Errorf("validator read %d bytes, want read = %v and at most %d", readBefore, tt.wantRead, maxValidatedRequest+1)
+			}
+			if !bytes.Equal(forwarded, []byte(tt.body)) {
+				t.Errorf("upstream got %d bytes, want the %d byte body unchanged", len(forwarded), len(tt.body))
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"pkg/logger"
+)
+
+// Config HTTP 서버 및 종료 절차 설정
+type Config struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// DrainPeriod 준비 상태를 내린 뒤 로드밸런서가 트래픽을 빼도록 기다리는 시간
+	DrainPeriod time.Duration
+	// ShutdownTimeout 진행 중인 요청, 워커, 종료 훅에 주어지는 전체 시간
+	ShutdownTimeout time.Duration
+}
+
+// Hook 시작 순서대로 Start, 역순으로 Stop이 호출되는 수명 주기 훅
+type Hook struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+type worker struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+// App HTTP 서버와 백그라운드 워커, 리소스의 시작/종료 순서를 관리
+//
+// 시작: 훅 Start (등록 순) → 워커 → HTTP 서버
+// 종료: OnShutdown 콜백 (준비 상태 해제) → DrainPeriod 대기 → HTTP 서버 종료 → 워커 중지 → 훅 Stop (역순)
+type App struct {
+	cfg        Config
+	log        logger.Logger
+	handler    http.Handler
+	hooks      []Hook
+	workers    []worker
+	onShutdown []func()
+}
+
+func New(cfg Config, log logger.Logger) *App {
+	return &App{
+		cfg: cfg,
+		log: log,
+	}
+}
+
+// Handle HTTP 서버 핸들러 설정
+func (a *App) Handle(handler http.Handler) {
+	a.handler = handler
+}
+
+// Hook 수명 주기 훅 등록
+func (a *App) Hook(hook Hook) {
+	a.hooks = append(a.hooks, hook)
+}
+
+// OnStop 종료 시 호출할 함수 등록 (DB 풀, 트레이서 등). 등록 역순으로 호출
+func (a *App) OnStop(name string, stop func(ctx context.Context) error) {
+	a.Hook(Hook{Name: name, Stop: stop})
+}
+
+// Go 서버와 함께 실행되는 백그라운드 워커 등록. ctx는 HTTP 서버 종료 후 취소됨
+func (a *App) Go(name string, run func(ctx context.Context)) {
+	a.workers = append(a.workers, worker{name: name, run: run})
+}
+
+// OnShutdown 종료 신호 직후 호출할 함수 등록 (예: 준비 상태 해제)
+func (a *App) OnShutdown(fn func()) {
+	a.onShutdown = append(a.onShutdown, fn)
+}
+
+// Run 애플리케이션을 시작하고 SIGINT/SIGTERM 또는 서버 오류까지 대기한 뒤 정리
+func (a *App) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return a.RunContext(ctx)
+}
+
+// RunContext ctx가 취소될 때까지 실행
+func (a *App) RunContext(ctx context.Context) error {
+	if a.handler == nil {
+		return errors.New("app: no handler configured")
+	}
+
+	started, err := a.start(ctx)
+	if err != nil {
+		a.stop(context.Background(), started)
+		return err
+	}
+
+	listener, err := net.Listen("tcp", a.cfg.Addr)
+	if err != nil {
+		a.stop(context.Background(), started)
+		return fmt.Errorf("failed to listen on %s: %w", a.cfg.Addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           a.handler,
+		ReadHeaderTimeout: a.cfg.ReadHeaderTimeout,
+		ReadTimeout:       a.cfg.ReadTimeout,
+		WriteTimeout:      a.cfg.WriteTimeout,
+		IdleTimeout:       a.cfg.IdleTimeout,
+	}
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	for _, w := range a.workers {
+		workers.Add(1)
+		go func(w worker) {
+			defer workers.Done()
+			w.run(workerCtx)
+		}(w)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		a.log.Info("Server starting", "addr", listener.Addr().String())
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		a.log.Info("Shutting down server...")
+	case err := <-serveErr:
+		runErr = fmt.Errorf("server failed: %w", err)
+		a.log.Error("Server failed", "error", err)
+	}
+
+	for _, fn := range a.onShutdown {
+		fn()
+	}
+
+	if runErr == nil && a.cfg.DrainPeriod > 0 {
+		a.log.Info("Draining connections", "period", a.cfg.DrainPeriod)
+		time.Sleep(a.cfg.DrainPeriod)
+	}
+
+	shutdownCtx, cancel := a.shutdownContext()
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		a.log.Error("Server forced to shutdown", "error", err)
+		runErr = errors.Join(runErr, err)
+	}
+
+	stopWorkers()
+	if !waitGroup(shutdownCtx, &workers) {
+		a.log.Warn("Background workers did not stop before the shutdown timeout")
+	}
+
+	if err := a.stop(shutdownCtx, started); err != nil {
+		runErr = errors.Join(runErr, err)
+	}
+
+	a.log.Info("Server exited")
+	return runErr
+}
+
+func (a *App) shutdownContext() (context.Context, context.CancelFunc) {
+	if a.cfg.ShutdownTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout)
+}
+
+// start 훅을 등록 순서대로 시작하고, 시작된 훅 목록을 반환
+func (a *App) start(ctx context.Context) ([]Hook, error) {
+	started := make([]Hook, 0, len(a.hooks))
+	for _, hook := range a.hooks {
+		if hook.Start != nil {
+			if err := hook.Start(ctx); err != nil {
+				return started, fmt.Errorf("failed to start %s: %w", hook.Name, err)
+			}
+		}
+		started = append(started, hook)
+	}
+	return started, nil
+}
+
+// stop 시작된 훅을 역순으로 종료
+func (a *App) stop(ctx context.Context, started []Hook) error {
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		hook := started[i]
+		if hook.Stop == nil {
+			continue
+		}
+		if err := hook.Stop(ctx); err != nil {
+			a.log.Error("Failed to stop component", "component", hook.Name, "error", err)
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", hook.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,63 @@
+package common_handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PingCheck 커넥션 풀로 DB 연결 확인
+func PingCheck(pool *pgxpool.Pool) Check {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
+
+// HTTPCheck URL에 GET 요청을 보내 2xx 응답인지 확인
+func HTTPCheck(client *http.Client, url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// MigrationCheck flyway가 적용한 최신 버전이 minVersion 이상인지 확인
+func MigrationCheck(pool *pgxpool.Pool, minVersion int) Check {
+	return func(ctx context.Context) error {
+		var version string
+		err := pool.QueryRow(ctx,
+			`SELECT version FROM flyway_schema_history
+			 WHERE success AND version IS NOT NULL
+			 ORDER BY installed_rank DESC
+			 LIMIT 1`,
+		).Scan(&version)
+		if err != nil {
+			return fmt.Errorf("failed to read migration version: %w", err)
+		}
+
+		applied, err := strconv.Atoi(version)
+		if err != nil {
+			return fmt.Errorf("unexpected migration version %q", version)
+		}
+		if applied < minVersion {
+			return fmt.Errorf("schema version %d is older than required %d", applied, minVersion)
+		}
+		return nil
+	}
+}
@@ -1,21 +1,42 @@
 package common_handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"pkg/models"
 )
 
+// defaultCheckTimeout 개별 readiness 체크의 기본 제한 시간
+const defaultCheckTimeout = 2 * time.Second
+
+// Check 의존성 상태 확인 함수. nil이 아닌 에러를 반환하면 해당 의존성은 비정상
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
 type HealthHandler struct {
-	serviceName string
-	version     string
+	serviceName  string
+	version      string
+	checkTimeout time.Duration
+
+	mu           sync.RWMutex
+	checks       []namedCheck
+	shuttingDown atomic.Bool
 }
 
 func NewHealthHandler(serviceName, version string) *HealthHandler {
 	return &HealthHandler{
-		serviceName: serviceName,
-		version:     version,
+		serviceName:  serviceName,
+		version:      version,
+		checkTimeout: defaultCheckTimeout,
 	}
 }
 
@@ -24,6 +45,29 @@ func (h *HealthHandler) Path() string {
 	return "/health"
 }
 
+// LivePath liveness 경로 반환
+func (h *HealthHandler) LivePath() string {
+	return "/health/live"
+}
+
+// ReadyPath readiness 경로 반환
+func (h *HealthHandler) ReadyPath() string {
+	return "/health/ready"
+}
+
+// AddCheck readiness 체크 등록
+func (h *HealthHandler) AddCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// MarkShuttingDown 종료 시작을 알림. 이후 readiness는 항상 실패
+func (h *HealthHandler) MarkShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Health 프로세스가 응답 가능한지만 확인 (liveness와 동일)
 func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 	response := models.NewHealthResponse(h.serviceName, h.version)
 
@@ -31,3 +75,68 @@ func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
+
+// Live 프로세스 생존 여부 확인. 의존성은 확인하지 않음
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	h.Health(w, r)
+}
+
+// Ready 등록된 모든 체크를 병렬로 실행하고 체크별 상태와 지연 시간을 반환
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	h.mu.RLock()
+	checks := append([]namedCheck{}, h.checks...)
+	h.mu.RUnlock()
+
+	results := make(map[string]models.CheckResult, len(checks))
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c namedCheck) {
+			defer wg.Done()
+			result := h.runCheck(r.Context(), c.check)
+
+			resultsMu.Lock()
+			results[c.name] = result
+			resultsMu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	ready := !h.shuttingDown.Load()
+	for _, result := range results {
+		if result.Status != models.HealthStatusUp {
+			ready = false
+		}
+	}
+
+	response := models.NewReadinessResponse(h.serviceName, h.version, ready, h.shuttingDown.Load(), results)
+
+	statusCode := http.StatusOK
+	if !ready {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *HealthHandler) runCheck(ctx context.Context, check Check) models.CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := models.CheckResult{
+		Status:    models.HealthStatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = models.HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
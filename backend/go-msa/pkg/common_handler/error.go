@@ -0,0 +1,100 @@
+package common_handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	apperrors "pkg/errors"
+	"pkg/logger"
+	"pkg/models"
+)
+
+// ErrorFormat 에러 응답 본문 형식
+type ErrorFormat string
+
+const (
+	// ErrorFormatJSON 기존 APIResponse 형식
+	ErrorFormatJSON ErrorFormat = "json"
+	// ErrorFormatProblem RFC 9457 application/problem+json 형식
+	ErrorFormatProblem ErrorFormat = "problem"
+)
+
+var defaultErrorFormat atomic.Value
+
+func init() {
+	defaultErrorFormat.Store(ErrorFormatJSON)
+}
+
+// ParseErrorFormat 설정 문자열을 ErrorFormat으로 변환
+func ParseErrorFormat(value string) (ErrorFormat, error) {
+	switch format := ErrorFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case ErrorFormatJSON, ErrorFormatProblem:
+		return format, nil
+	case "":
+		return ErrorFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown error format %q", value)
+	}
+}
+
+// SetErrorFormat Accept 헤더로 problem+json을 요청하지 않은 클라이언트에 사용할 기본 형식 설정
+func SetErrorFormat(format ErrorFormat) {
+	defaultErrorFormat.Store(format)
+}
+
+// negotiateErrorFormat Accept 헤더에 application/problem+json이 있으면 problem 형식, 없으면 기본 형식 사용
+func negotiateErrorFormat(r *http.Request) ErrorFormat {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mediaType != models.ProblemContentType {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+			return ErrorFormatProblem
+		}
+	}
+
+	return defaultErrorFormat.Load().(ErrorFormat)
+}
+
+// WriteError 요청 ID를 포함한 에러 응답 작성
+func WriteError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
+	WriteAppError(w, r, apperrors.New(statusCode, code, message))
+}
+
+// WriteAppError AppError를 협상된 형식(APIResponse 또는 problem+json)으로 작성
+func WriteAppError(w http.ResponseWriter, r *http.Request, appErr *apperrors.AppError) {
+	requestID := logger.RequestIDFromContext(r.Context())
+
+	if negotiateErrorFormat(r) == ErrorFormatProblem {
+		problem := models.NewProblemDetails(appErr.StatusCode, appErr.Message, r.URL.Path)
+		problem.Extensions["code"] = appErr.Code
+		if requestID != "" {
+			problem.Extensions["request_id"] = requestID
+		}
+		if len(appErr.Fields) > 0 {
+			problem.Extensions["errors"] = appErr.Fields
+		}
+
+		w.Header().Set("Content-Type", models.ProblemContentType)
+		w.WriteHeader(appErr.StatusCode)
+		_ = json.NewEncoder(w).Encode(problem)
+		return
+	}
+
+	response := models.NewErrorResponse(appErr.Code, appErr.Message, "")
+	response.Error.Fields = appErr.Fields
+	response.RequestID = requestID
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.StatusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
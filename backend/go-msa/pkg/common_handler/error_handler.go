@@ -0,0 +1,88 @@
+package common_handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	apperrors "pkg/errors"
+	"pkg/logger"
+)
+
+// HandlerFunc 에러를 반환하는 HTTP 핸들러. 반환된 에러는 ErrorHandler가 응답으로 변환
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+type errorMapping struct {
+	target     error
+	statusCode int
+	code       string
+}
+
+// ErrorHandler 핸들러 에러를 상태 코드와 APIResponse 또는 problem+json 응답으로 변환
+type ErrorHandler struct {
+	log      logger.Logger
+	mappings []errorMapping
+}
+
+func NewErrorHandler(log logger.Logger) *ErrorHandler {
+	return &ErrorHandler{
+		log: log,
+	}
+}
+
+// Map 도메인 sentinel 에러를 상태 코드와 에러 코드에 매핑. 메시지는 sentinel 자체의 문구만 노출
+func (h *ErrorHandler) Map(target error, statusCode int, code string) *ErrorHandler {
+	h.mappings = append(h.mappings, errorMapping{target: target, statusCode: statusCode, code: code})
+	return h
+}
+
+// Handle HandlerFunc를 http.HandlerFunc로 변환
+func (h *ErrorHandler) Handle(fn HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			h.WriteError(w, r, err)
+		}
+	}
+}
+
+// WriteError 에러 응답 작성. 5xx 에러는 요청 ID와 함께 로그로 남기고 클라이언트에는 내부 정보를 숨김
+func (h *ErrorHandler) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	appErr := h.Resolve(err)
+
+	if appErr.StatusCode >= http.StatusInternalServerError {
+		h.log.ErrorContext(r.Context(), "Request failed",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", appErr.StatusCode,
+			"error", err,
+		)
+	}
+
+	WriteAppError(w, r, appErr)
+}
+
+// Resolve AppError → 등록된 sentinel → pgx.ErrNoRows → 요청 타임아웃 순으로 매핑하고 나머지는 500으로 처리
+// HTTP 외의 전송 계층(gRPC 등)도 같은 매핑을 쓰도록 공개
+func (h *ErrorHandler) Resolve(err error) *apperrors.AppError {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	for _, m := range h.mappings {
+		if errors.Is(err, m.target) {
+			return apperrors.New(m.statusCode, m.code, m.target.Error())
+		}
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.NewNotFoundError("Resource not found")
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperrors.NewInternalError("Request timed out", err).WithStatus(http.StatusGatewayTimeout, "TIMEOUT")
+	}
+
+	return apperrors.NewInternalError("Internal server error", err)
+}
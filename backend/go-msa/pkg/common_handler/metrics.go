@@ -0,0 +1,27 @@
+package common_handler
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsHandler struct {
+	handler http.Handler
+}
+
+func NewMetricsHandler(registry *prometheus.Registry) *MetricsHandler {
+	return &MetricsHandler{
+		handler: promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}),
+	}
+}
+
+// Path 메트릭 수집 경로 반환
+func (h *MetricsHandler) Path() string {
+	return "/metrics"
+}
+
+func (h *MetricsHandler) Metrics(w http.ResponseWriter, r *http.Request) {
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,321 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the environment variable holding the optional YAML config file path
+const ConfigFileEnv = "CONFIG_FILE"
+
+// redactedValue replaces secret values in Redacted output
+const redactedValue = "[REDACTED]"
+
+// field is a leaf configuration value discovered from struct tags
+type field struct {
+	path     string
+	env      string
+	flag     string
+	def      string
+	hasDef   bool
+	required bool
+	secret   bool
+	value    reflect.Value
+}
+
+// name identifies the field in errors by its environment variable, or else its YAML path
+func (f field) name() string {
+	if f.env != "" {
+		return f.env
+	}
+	return f.path
+}
+
+// Load fills dst from struct tags, layering defaults, a YAML file, environment
+// variables and command line flags, in that order of precedence. Leaf fields use:
+//
+//	env:"DB_HOST"     environment variable name, or DB_HOST_FILE for a file holding the value;
+//	                  also derives the flag name (--db-host)
+//	default:"5432"    value used when no other layer sets the field
+//	required:"true"   the field must not be empty after loading
+//	secret:"true"     redacted in Redacted; ResolveSecrets looks it up in a SecretProvider
+//	                  and, in production, requires a non-default value
+//	yaml:"host"       key in the YAML file
+//
+// The YAML file is read from --config or CONFIG_FILE. Malformed values are reported
+// instead of falling back to defaults.
+func Load(dst interface{}) error {
+	return LoadWithArgs(dst, os.Args[1:])
+}
+
+// LoadWithArgs behaves like Load with explicit command line arguments
+func LoadWithArgs(dst interface{}, args []string) error {
+	root := reflect.ValueOf(dst)
+	if root.Kind() != reflect.Pointer || root.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: destination must be a pointer to a struct, got %T", dst)
+	}
+
+	fields := collectFields(root.Elem(), "")
+
+	for _, f := range fields {
+		if !f.hasDef {
+			continue
+		}
+		if err := setValue(f.value, f.def); err != nil {
+			return fmt.Errorf("config: invalid default for %s: %w", f.path, err)
+		}
+	}
+
+	flags := flag.NewFlagSet(commandName(), flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	configFile := flags.String("config", "", "path to YAML config file")
+	flagValues := make(map[string]*string, len(fields))
+	for _, f := range fields {
+		if f.flag != "" {
+			flagValues[f.flag] = flags.String(f.flag, f.def, "sets "+f.env)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path != "" {
+		if err := loadFile(dst, path); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+		value, ok, err := lookupEnv(f.env)
+		if err != nil {
+			return fmt.Errorf("config: %w", err)
+		}
+		if !ok {
+			continue
+		}
+		if err := setValue(f.value, value); err != nil {
+			return fmt.Errorf("config: invalid value for %s: %w", f.env, err)
+		}
+	}
+
+	var flagErr error
+	byFlag := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byFlag[f.flag] = f
+	}
+	flags.Visit(func(fl *flag.Flag) {
+		f, ok := byFlag[fl.Name]
+		if !ok || flagErr != nil {
+			return
+		}
+		if err := setValue(f.value, *flagValues[fl.Name]); err != nil {
+			flagErr = fmt.Errorf("config: invalid value for --%s: %w", fl.Name, err)
+		}
+	})
+	if flagErr != nil {
+		return flagErr
+	}
+
+	return validate(fields)
+}
+
+// IsProduction reports whether APP_ENV selects the production environment
+func IsProduction() bool {
+	switch strings.ToLower(GetEnv("APP_ENV", "development")) {
+	case "production", "prod":
+		return true
+	default:
+		return false
+	}
+}
+
+// Redacted returns the effective configuration keyed by YAML names with secret values masked
+func Redacted(cfg interface{}) map[string]interface{} {
+	value := reflect.ValueOf(cfg)
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	return redactStruct(value)
+}
+
+func redactStruct(value reflect.Value) map[string]interface{} {
+	out := make(map[string]interface{})
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := yamlName(sf)
+		fv := value.Field(i)
+		switch {
+		case isNested(sf, fv):
+			out[name] = redactStruct(fv)
+		case sf.Tag.Get("secret") == "true":
+			if fv.IsZero() {
+				out[name] = ""
+			} else {
+				out[name] = redactedValue
+			}
+		case fv.Type() == reflect.TypeOf(time.Duration(0)):
+			out[name] = time.Duration(fv.Int()).String()
+		default:
+			out[name] = fv.Interface()
+		}
+	}
+	return out
+}
+
+func collectFields(value reflect.Value, prefix string) []field {
+	var fields []field
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		path := yamlName(sf)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		fv := value.Field(i)
+		if isNested(sf, fv) {
+			fields = append(fields, collectFields(fv, path)...)
+			continue
+		}
+
+		def, hasDef := sf.Tag.Lookup("default")
+		f := field{
+			path:     path,
+			env:      sf.Tag.Get("env"),
+			def:      def,
+			hasDef:   hasDef,
+			required: sf.Tag.Get("required") == "true",
+			secret:   sf.Tag.Get("secret") == "true",
+			value:    fv,
+		}
+		if f.env != "" {
+			f.flag = strings.ReplaceAll(strings.ToLower(f.env), "_", "-")
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// isNested reports whether a struct field groups other settings rather than holding a value
+func isNested(sf reflect.StructField, value reflect.Value) bool {
+	return value.Kind() == reflect.Struct && sf.Tag.Get("env") == ""
+}
+
+func yamlName(sf reflect.StructField) string {
+	if tag := sf.Tag.Get("yaml"); tag != "" {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return strings.ToLower(sf.Name)
+}
+
+func loadFile(dst interface{}, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config: failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+func setValue(value reflect.Value, raw string) error {
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(n)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", value.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// validate checks required fields. Secrets are checked by ResolveSecrets once their provider has been consulted.
+func validate(fields []field) error {
+	var errs []error
+	for _, f := range fields {
+		if f.required && f.value.IsZero() {
+			errs = append(errs, fmt.Errorf("%s is required", f.name()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func commandName() string {
+	if len(os.Args) > 0 {
+		return os.Args[0]
+	}
+	return "service"
+}
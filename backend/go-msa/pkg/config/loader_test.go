@@ -0,0 +1,179 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type loaderTestConfig struct {
+	Server struct {
+		Port    int           `yaml:"port" env:"TEST_PORT" default:"8080"`
+		Host    string        `yaml:"host" env:"TEST_HOST" default:"localhost"`
+		Timeout time.Duration `yaml:"timeout" env:"TEST_TIMEOUT" default:"5s"`
+		Debug   bool          `yaml:"debug" env:"TEST_DEBUG" default:"false"`
+		Origins []string      `yaml:"origins" env:"TEST_ORIGINS"`
+	} `yaml:"server"`
+	Database struct {
+		Name     string `yaml:"name" env:"TEST_DB_NAME" required:"true"`
+		Password string `yaml:"password" env:"TEST_DB_PASSWORD" default:"password" secret:"true"`
+	} `yaml:"database"`
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	t.Setenv("TEST_DB_NAME", "app")
+	file := writeFile(t, t.TempDir(), "config.yaml", "server:\n  port: 9000\n  host: yaml-host\n  timeout: 10s\n")
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		args     []string
+		wantPort int
+		wantHost string
+		wantTime time.Duration
+	}{
+		{name: "defaults", wantPort: 8080, wantHost: "localhost", wantTime: 5 * time.Second},
+		{name: "yaml over defaults", env: map[string]string{ConfigFileEnv: file}, wantPort: 9000, wantHost: "yaml-host", wantTime: 10 * time.Second},
+		{name: "--config selects the file", args: []string{"--config", file}, wantPort: 9000, wantHost: "yaml-host", wantTime: 10 * time.Second},
+		{
+			name:     "env over yaml",
+			env:      map[string]string{ConfigFileEnv: file, "TEST_PORT": "9100"},
+			wantPort: 9100, wantHost: "yaml-host", wantTime: 10 * time.Second,
+		},
+		{
+			name:     "flags over env",
+			env:      map[string]string{ConfigFileEnv: file, "TEST_PORT": "9100", "TEST_TIMEOUT": "1m"},
+			args:     []string{"--test-port=9200"},
+			wantPort: 9200, wantHost: "yaml-host", wantTime: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{ConfigFileEnv, "TEST_PORT", "TEST_TIMEOUT"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			var cfg loaderTestConfig
+			if err := LoadWithArgs(&cfg, tt.args); err != nil {
+				t.Fatalf("LoadWithArgs() error = %v", err)
+			}
+			if cfg.Server.Port != tt.wantPort || cfg.Server.Host != tt.wantHost || cfg.Server.Timeout != tt.wantTime {
+				t.Errorf("server = %d %q %v, want %d %q %v",
+					cfg.Server.Port, cfg.Server.Host, cfg.Server.Timeout, tt.wantPort, tt.wantHost, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestLoadValues(t *testing.T) {
+	t.Setenv("TEST_DB_NAME", "app")
+	t.Setenv("TEST_DEBUG", "true")
+	t.Setenv("TEST_ORIGINS", " https://a.example.com, ,https://b.example.com ")
+	// NAME_FILE wins over NAME
+	t.Setenv("TEST_DB_PASSWORD", "from-env")
+	t.Setenv("TEST_DB_PASSWORD_FILE", writeFile(t, t.TempDir(), "db_password", "from-file\n"))
+
+	var cfg loaderTestConfig
+	if err := LoadWithArgs(&cfg, nil); err != nil {
+		t.Fatalf("LoadWithArgs() error = %v", err)
+	}
+
+	if !cfg.Server.Debug {
+		t.Error("Debug = false, want true")
+	}
+	if want := []string{"https://a.example.com", "https://b.example.com"}; !reflect.DeepEqual(cfg.Server.Origins, want) {
+		t.Errorf("Origins = %v, want %v", cfg.Server.Origins, want)
+	}
+	if cfg.Database.Password != "from-file" {
+		t.Errorf("Password = %q, want from-file", cfg.Database.Password)
+	}
+}
+
+func TestLoadRejectsMalformedValues(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		yaml string
+		args []string
+		want string
+	}{
+		{name: "env integer", env: map[string]string{"TEST_PORT": "80a"}, want: "TEST_PORT"},
+		{name: "env duration without unit", env: map[string]string{"TEST_TIMEOUT": "30"}, want: "TEST_TIMEOUT"},
+		{name: "env boolean", env: map[string]string{"TEST_DEBUG": "yes please"}, want: "TEST_DEBUG"},
+		{name: "flag integer", args: []string{"--test-port", "eighty"}, want: "--test-port"},
+		{name: "unknown flag", args: []string{"--no-such-flag"}, want: "no-such-flag"},
+		{name: "yaml type", yaml: "server:\n  port: eighty\n", want: "failed to parse"},
+		{name: "yaml unknown key", yaml: "server:\n  prot: 80\n", want: "failed to parse"},
+		{name: "missing file", env: map[string]string{ConfigFileEnv: "/nonexistent/config.yaml"}, want: "failed to open"},
+		{name: "unreadable _FILE", env: map[string]string{"TEST_DB_PASSWORD_FILE": "/nonexistent/secret"}, want: "TEST_DB_PASSWORD_FILE"},
+		{name: "required", env: map[string]string{"TEST_DB_NAME": ""}, want: "TEST_DB_NAME is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_DB_NAME", "app")
+			if tt.yaml != "" {
+				t.Setenv(ConfigFileEnv, writeFile(t, t.TempDir(), "config.yaml", tt.yaml))
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			var cfg loaderTestConfig
+			err := LoadWithArgs(&cfg, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsDefaultSecretsInProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("TEST_DB_NAME", "app")
+
+	// Load accepts the default, since the secret provider may still supply the value
+	var cfg loaderTestConfig
+	if err := LoadWithArgs(&cfg, nil); err != nil {
+		t.Fatalf("LoadWithArgs() error = %v", err)
+	}
+
+	err := ResolveSecrets(context.Background(), &cfg, &mapSecretProvider{})
+	if err == nil || !strings.Contains(err.Error(), "TEST_DB_PASSWORD must not use the insecure default in production") {
+		t.Errorf("ResolveSecrets() error = %v", err)
+	}
+}
+
+func TestLoadRejectsNonStruct(t *testing.T) {
+	var cfg loaderTestConfig
+	if err := LoadWithArgs(cfg, nil); err == nil {
+		t.Error("a non-pointer destination was accepted")
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	var cfg loaderTestConfig
+	cfg.Server.Port = 8080
+	cfg.Server.Timeout = 90 * time.Second
+	cfg.Database.Name = "app"
+	cfg.Database.Password = "hunter2"
+
+	got := Redacted(&cfg)
+
+	database := got["database"].(map[string]interface{})
+	if database["password"] != redactedValue || database["name"] != "app" {
+		t.Errorf("database = %v", database)
+	}
+	server := got["server"].(map[string]interface{})
+	if server["timeout"] != "1m30s" || server["port"] != 8080 {
+		t.Errorf("server = %v", server)
+	}
+
+	cfg.Database.Password = ""
+	if password := Redacted(cfg)["database"].(map[string]interface{})["password"]; password != "" {
+		t.Errorf("empty secret redacted as %q, want it shown empty", password)
+	}
+}
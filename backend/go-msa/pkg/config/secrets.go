@@ -0,0 +1,268 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"pkg/logger"
+)
+
+// FileEnvSuffix marks an environment variable that holds the path of a file containing
+// the value, e.g. DB_PASSWORD_FILE=/run/secrets/db_password
+const FileEnvSuffix = "_FILE"
+
+// ErrSecretNotFound is returned when a provider has no value for a secret
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretProvider resolves secret values by name
+type SecretProvider interface {
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// NewSecretProvider creates the provider selected by kind: "env" or "file".
+// The file provider falls back to environment variables for secrets without a file.
+func NewSecretProvider(kind, dir string) (SecretProvider, error) {
+	switch kind {
+	case "", "env":
+		return NewEnvSecretProvider(), nil
+	case "file":
+		return NewChainSecretProvider(NewFileSecretProvider(dir), NewEnvSecretProvider()), nil
+	default:
+		return nil, fmt.Errorf("unknown secret provider %q", kind)
+	}
+}
+
+// ResolveSecrets replaces the secret:"true" fields of cfg, as filled by Load, with the value provider holds
+// under their env name, so a secret may exist only in the provider. Fields the provider does not know keep
+// their loaded value. In production, secrets that are still empty or at their insecure default are rejected.
+func ResolveSecrets(ctx context.Context, cfg interface{}, provider SecretProvider) error {
+	root := reflect.ValueOf(cfg)
+	if root.Kind() != reflect.Pointer || root.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: destination must be a pointer to a struct, got %T", cfg)
+	}
+	production := IsProduction()
+
+	var errs []error
+	for _, f := range collectFields(root.Elem(), "") {
+		if !f.secret {
+			continue
+		}
+
+		if f.env != "" {
+			value, err := provider.GetSecret(ctx, f.env)
+			switch {
+			case errors.Is(err, ErrSecretNotFound):
+			case err != nil:
+				return fmt.Errorf("config: failed to load secret %s: %w", f.env, err)
+			default:
+				if err := setValue(f.value, value); err != nil {
+					return fmt.Errorf("config: invalid value for %s: %w", f.env, err)
+				}
+			}
+		}
+
+		if !production {
+			continue
+		}
+		switch {
+		case f.value.IsZero():
+			errs = append(errs, fmt.Errorf("%s must be set in production", f.name()))
+		case f.hasDef && f.def != "" && fmt.Sprint(f.value.Interface()) == f.def:
+			errs = append(errs, fmt.Errorf("%s must not use the insecure default in production", f.name()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// EnvSecretProvider reads NAME_FILE if set, otherwise NAME
+type EnvSecretProvider struct{}
+
+func NewEnvSecretProvider() *EnvSecretProvider {
+	return &EnvSecretProvider{}
+}
+
+func (p *EnvSecretProvider) GetSecret(_ context.Context, name string) (string, error) {
+	value, ok, err := lookupEnv(name)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
+
+// FileSecretProvider reads secrets from files named after the lowercased secret name,
+// matching Docker and Kubernetes secret mounts (DB_PASSWORD -> <dir>/db_password)
+type FileSecretProvider struct {
+	dir string
+}
+
+func NewFileSecretProvider(dir string) *FileSecretProvider {
+	return &FileSecretProvider{
+		dir: dir,
+	}
+}
+
+func (p *FileSecretProvider) GetSecret(_ context.Context, name string) (string, error) {
+	value, err := readSecretFile(filepath.Join(p.dir, strings.ToLower(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return value, err
+}
+
+// ChainSecretProvider returns the first value found across providers
+type ChainSecretProvider struct {
+	providers []SecretProvider
+}
+
+func NewChainSecretProvider(providers ...SecretProvider) *ChainSecretProvider {
+	return &ChainSecretProvider{
+		providers: providers,
+	}
+}
+
+func (p *ChainSecretProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	for _, provider := range p.providers {
+		value, err := provider.GetSecret(ctx, name)
+		if errors.Is(err, ErrSecretNotFound) {
+			continue
+		}
+		return value, err
+	}
+	return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+}
+
+// Secret holds the current value of a secret and refreshes it from its provider,
+// so rotated credentials and signing keys are picked up without a restart
+type Secret struct {
+	name     string
+	provider SecretProvider
+
+	mu        sync.RWMutex
+	value     string
+	listeners []func(value string)
+}
+
+// NewSecret loads name from provider, using fallback when the provider has no value
+func NewSecret(ctx context.Context, provider SecretProvider, name, fallback string) (*Secret, error) {
+	s := &Secret{
+		name:     name,
+		provider: provider,
+		value:    fallback,
+	}
+	if _, err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Name returns the secret name
+func (s *Secret) Name() string {
+	return s.name
+}
+
+// Value returns the current secret value
+func (s *Secret) Value() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.value
+}
+
+// OnChange registers fn to be called with the new value after a rotation
+func (s *Secret) OnChange(fn func(value string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// Refresh reloads the secret and reports whether the value changed.
+// A secret missing from the provider keeps its current value.
+func (s *Secret) Refresh(ctx context.Context) (bool, error) {
+	value, err := s.provider.GetSecret(ctx, s.name)
+	if errors.Is(err, ErrSecretNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load secret %s: %w", s.name, err)
+	}
+
+	s.mu.Lock()
+	if value == s.value {
+		s.mu.Unlock()
+		return false, nil
+	}
+	s.value = value
+	listeners := append([]func(string){}, s.listeners...)
+	s.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(value)
+	}
+	return true, nil
+}
+
+// Watch refreshes the secret every interval until ctx is cancelled.
+// A non-positive interval disables refreshing; the secret keeps its loaded value.
+func (s *Secret) Watch(ctx context.Context, interval time.Duration, log logger.Logger) {
+	if interval <= 0 {
+		log.Info("Secret refresh disabled", "secret", s.name)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.Refresh(ctx)
+			if err != nil {
+				log.Warn("Failed to refresh secret", "secret", s.name, "error", err)
+				continue
+			}
+			if changed {
+				log.Info("Secret rotated", "secret", s.name)
+			}
+		}
+	}
+}
+
+// lookupEnv reads name, preferring the file referenced by name_FILE
+func lookupEnv(name string) (string, bool, error) {
+	if path := os.Getenv(name + FileEnvSuffix); path != "" {
+		value, err := readSecretFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read %s%s: %w", name, FileEnvSuffix, err)
+		}
+		return value, true, nil
+	}
+
+	if value := os.Getenv(name); value != "" {
+		return value, true, nil
+	}
+	return "", false, nil
+}
+
+// readSecretFile reads a secret file, trimming the trailing newline editors and echo add
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
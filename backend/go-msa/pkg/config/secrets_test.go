@@ -0,0 +1,247 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"pkg/logger"
+)
+
+// mapSecretProvider serves secrets from a map, failing with err when set
+type mapSecretProvider struct {
+	values map[string]string
+	err    error
+}
+
+func (p *mapSecretProvider) GetSecret(_ context.Context, name string) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+	value, ok := p.values[name]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestEnvSecretProvider(t *testing.T) {
+	ctx := context.Background()
+	provider := NewEnvSecretProvider()
+
+	t.Setenv("TEST_SECRET", "from-env")
+	if value, err := provider.GetSecret(ctx, "TEST_SECRET"); err != nil || value != "from-env" {
+		t.Errorf("GetSecret() = %q, %v, want from-env", value, err)
+	}
+
+	// NAME_FILE wins over NAME, and the trailing newline is dropped
+	t.Setenv("TEST_SECRET_FILE", writeFile(t, t.TempDir(), "secret", "from-file\n"))
+	if value, err := provider.GetSecret(ctx, "TEST_SECRET"); err != nil || value != "from-file" {
+		t.Errorf("GetSecret() = %q, %v, want from-file", value, err)
+	}
+
+	t.Setenv("TEST_SECRET_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, err := provider.GetSecret(ctx, "TEST_SECRET"); err == nil || errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("GetSecret() with an unreadable NAME_FILE error = %v, want a read error", err)
+	}
+
+	if _, err := provider.GetSecret(ctx, "TEST_UNSET_SECRET"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("GetSecret() error = %v, want ErrSecretNotFound", err)
+	}
+}
+
+func TestFileSecretProvider(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeFile(t, dir, "db_password", "s3cret\r\n")
+	provider := NewFileSecretProvider(dir)
+
+	if value, err := provider.GetSecret(ctx, "DB_PASSWORD"); err != nil || value != "s3cret" {
+		t.Errorf("GetSecret() = %q, %v, want s3cret", value, err)
+	}
+	if _, err := provider.GetSecret(ctx, "JWT_SECRET"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("GetSecret() error = %v, want ErrSecretNotFound", err)
+	}
+}
+
+func TestChainSecretProvider(t *testing.T) {
+	ctx := context.Background()
+	first := &mapSecretProvider{values: map[string]string{"A": "first"}}
+	second := &mapSecretProvider{values: map[string]string{"A": "second", "B": "second"}}
+	provider := NewChainSecretProvider(first, second)
+
+	for name, want := range map[string]string{"A": "first", "B": "second"} {
+		if value, err := provider.GetSecret(ctx, name); err != nil || value != want {
+			t.Errorf("GetSecret(%s) = %q, %v, want %q", name, value, err, want)
+		}
+	}
+	if _, err := provider.GetSecret(ctx, "C"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("GetSecret(C) error = %v, want ErrSecretNotFound", err)
+	}
+
+	failing := errors.New("vault unavailable")
+	provider = NewChainSecretProvider(&mapSecretProvider{err: failing}, second)
+	if _, err := provider.GetSecret(ctx, "A"); !errors.Is(err, failing) {
+		t.Errorf("GetSecret() error = %v, want the first provider's failure", err)
+	}
+}
+
+func TestNewSecretProvider(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "db_password", "from-file")
+	t.Setenv("JWT_SECRET", "from-env")
+
+	provider, err := NewSecretProvider("file", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The file provider falls back to the environment
+	for name, want := range map[string]string{"DB_PASSWORD": "from-file", "JWT_SECRET": "from-env"} {
+		if value, err := provider.GetSecret(context.Background(), name); err != nil || value != want {
+			t.Errorf("GetSecret(%s) = %q, %v, want %q", name, value, err, want)
+		}
+	}
+
+	if _, err := NewSecretProvider("vault", ""); err == nil {
+		t.Error("unknown provider accepted")
+	}
+}
+
+func TestSecretRefresh(t *testing.T) {
+	ctx := context.Background()
+	provider := &mapSecretProvider{values: map[string]string{}}
+
+	secret, err := NewSecret(ctx, provider, "DB_PASSWORD", "fallback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret.Value() != "fallback" {
+		t.Errorf("Value() = %q, want the fallback when the provider has none", secret.Value())
+	}
+
+	var rotated []string
+	secret.OnChange(func(value string) { rotated = append(rotated, value) })
+
+	provider.values["DB_PASSWORD"] = "v2"
+	if changed, err := secret.Refresh(ctx); err != nil || !changed {
+		t.Errorf("Refresh() = %v, %v, want a change", changed, err)
+	}
+	if changed, err := secret.Refresh(ctx); err != nil || changed {
+		t.Errorf("Refresh() = %v, %v, want no change for the same value", changed, err)
+	}
+
+	// A secret removed from the provider keeps its last value
+	delete(provider.values, "DB_PASSWORD")
+	if changed, err := secret.Refresh(ctx); err != nil || changed || secret.Value() != "v2" {
+		t.Errorf("Refresh() = %v, %v, value %q, want v2 kept", changed, err, secret.Value())
+	}
+
+	provider.err = errors.New("unavailable")
+	if _, err := secret.Refresh(ctx); err == nil || secret.Value() != "v2" {
+		t.Errorf("Refresh() error = %v, value %q, want an error and v2 kept", err, secret.Value())
+	}
+
+	if strings.Join(rotated, ",") != "v2" {
+		t.Errorf("OnChange calls = %v, want [v2]", rotated)
+	}
+}
+
+func TestSecretWatchWithoutInterval(t *testing.T) {
+	secret, err := NewSecret(context.Background(), &mapSecretProvider{}, "DB_PASSWORD", "fallback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := logger.NewWithWriters("test", "error", "json", io.Discard, io.Discard)
+
+	done := make(chan struct{})
+	go func() {
+		secret.Watch(context.Background(), 0, log)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Watch with a zero interval did not return")
+	}
+}
+
+type secretsTestConfig struct {
+	Database struct {
+		Password string `yaml:"password" env:"DB_PASSWORD" default:"password" secret:"true"`
+	} `yaml:"database"`
+	JWT struct {
+		Secret string `yaml:"secret" env:"JWT_SECRET" secret:"true"`
+	} `yaml:"jwt"`
+}
+
+func TestResolveSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		production bool
+		values     map[string]string
+		wantErr    []string
+		wantDB     string
+	}{
+		{
+			name:       "provider supplies secrets left at their defaults",
+			production: true,
+			values:     map[string]string{"DB_PASSWORD": "from-file", "JWT_SECRET": "signing-key"},
+			wantDB:     "from-file",
+		},
+		{
+			name:       "default and empty secrets are rejected in production",
+			production: true,
+			wantErr:    []string{"DB_PASSWORD must not use the insecure default", "JWT_SECRET must be set in production"},
+			wantDB:     "password",
+		},
+		{
+			name:   "defaults are allowed outside production",
+			wantDB: "password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.production {
+				t.Setenv("APP_ENV", "production")
+			} else {
+				t.Setenv("APP_ENV", "development")
+			}
+
+			var cfg secretsTestConfig
+			if err := LoadWithArgs(&cfg, nil); err != nil {
+				t.Fatalf("LoadWithArgs() error = %v", err)
+			}
+			err := ResolveSecrets(ctx, &cfg, &mapSecretProvider{values: tt.values})
+
+			for _, want := range tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %v, want it to mention %q", err, want)
+				}
+			}
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Errorf("error = %v", err)
+			}
+			if cfg.Database.Password != tt.wantDB {
+				t.Errorf("Database.Password = %q, want %q", cfg.Database.Password, tt.wantDB)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package errors
 import (
 	"fmt"
 	"net/http"
+
+	"pkg/models"
 )
 
 // AppError represents an application error
@@ -10,6 +12,7 @@ type AppError struct {
 	Code       string
 	Message    string
 	StatusCode int
+	Fields     []models.FieldError
 	Err        error
 }
 
@@ -25,6 +28,22 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// WithStatus overrides the status code and error code, keeping the message and cause
+func (e *AppError) WithStatus(statusCode int, code string) *AppError {
+	e.StatusCode = statusCode
+	e.Code = code
+	return e
+}
+
+// New creates an AppError with an explicit status code
+func New(statusCode int, code, message string) *AppError {
+	return &AppError{
+		Code:       code,
+		Message:    message,
+		StatusCode: statusCode,
+	}
+}
+
 // NewBadRequestError Common error constructors
 func NewBadRequestError(message string) *AppError {
 	return &AppError{
@@ -34,6 +53,16 @@ func NewBadRequestError(message string) *AppError {
 	}
 }
 
+// NewValidationError creates a bad request error listing the invalid fields
+func NewValidationError(message string, fields []models.FieldError) *AppError {
+	return &AppError{
+		Code:       "VALIDATION_ERROR",
+		Message:    message,
+		StatusCode: http.StatusBadRequest,
+		Fields:     fields,
+	}
+}
+
 func NewUnauthorizedError(message string) *AppError {
 	return &AppError{
 		Code:       "UNAUTHORIZED",
@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// SpecVersion is the envelope format version; consumers reject envelopes with a different major version
+const SpecVersion = "1.0"
+
+// Event is the versioned JSON envelope shared by every publisher and subscriber
+type Event struct {
+	SpecVersion string          `json:"specversion"`
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Source      string          `json:"source"`
+	Subject     string          `json:"subject,omitempty"`
+	Time        time.Time       `json:"time"`
+	TraceParent string          `json:"traceparent,omitempty"`
+	TraceState  string          `json:"tracestate,omitempty"`
+	Data        json.RawMessage `json:"data,omitempty"`
+}
+
+// NewEvent builds an envelope around data, stamping it with a fresh ID and the trace context from ctx
+func NewEvent(ctx context.Context, source, eventType string, data any) (*Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event data: %w", err)
+	}
+
+	traceParent, traceState := TraceContextFromContext(ctx)
+	return &Event{
+		SpecVersion: SpecVersion,
+		ID:          uuid.NewString(),
+		Type:        eventType,
+		Source:      source,
+		Time:        time.Now().UTC(),
+		TraceParent: traceParent,
+		TraceState:  traceState,
+		Data:        payload,
+	}, nil
+}
+
+// DecodeData unmarshals the event payload into v
+func (e *Event) DecodeData(v any) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s event data: %w", e.Type, err)
+	}
+	return nil
+}
+
+// Marshal encodes the envelope as JSON
+func (e *Event) Marshal() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Unmarshal decodes and validates an envelope
+func Unmarshal(data []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode event envelope: %w", err)
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// Validate checks the fields every consumer relies on
+func (e *Event) Validate() error {
+	if majorVersion(e.SpecVersion) != majorVersion(SpecVersion) {
+		return fmt.Errorf("unsupported event specversion %q", e.SpecVersion)
+	}
+	if e.ID == "" || e.Type == "" || e.Source == "" {
+		return fmt.Errorf("event envelope requires id, type and source")
+	}
+	return nil
+}
+
+func majorVersion(version string) string {
+	for i, r := range version {
+		if r == '.' {
+			return version[:i]
+		}
+	}
+	return version
+}
+
+// TraceContextFromContext renders the span context in ctx as W3C traceparent/tracestate values
+func TraceContextFromContext(ctx context.Context) (traceParent, traceState string) {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent"), carrier.Get("tracestate")
+}
+
+// ContextWithTrace returns ctx carrying the event's trace context as the remote parent,
+// so spans started by a consumer join the producer's trace
+func (e *Event) ContextWithTrace(ctx context.Context) context.Context {
+	if e.TraceParent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{
+		"traceparent": e.TraceParent,
+		"tracestate":  e.TraceState,
+	})
+}
@@ -0,0 +1,26 @@
+// Package events provides asynchronous messaging between services.
+//
+// Publishers send Event envelopes to a topic and subscribers receive them
+// through a Handler. Delivery guarantees depend on the backend: the Postgres
+// backend delivers at most once, while brokers that redeliver unacknowledged
+// events may deliver one twice, so consumers that must not apply an event
+// twice wrap their handler with Idempotent.
+package events
+
+import "context"
+
+// Handler processes a single event; returning an error reports the delivery as failed
+type Handler func(ctx context.Context, event *Event) error
+
+// Publisher sends events to a topic
+type Publisher interface {
+	Publish(ctx context.Context, topic string, event *Event) error
+	Close() error
+}
+
+// Subscriber delivers events published on a topic to a handler.
+// Subscribe returns once the subscription is active; delivery stops when ctx is cancelled or the subscriber is closed.
+type Subscriber interface {
+	Subscribe(ctx context.Context, topic string, handler Handler) error
+	Close() error
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestEventMarshalRoundTrip(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	event, err := NewEvent(ctx, "business-service", "product.created", map[string]int{"stock": 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ID == "" || event.SpecVersion != SpecVersion || event.TraceParent == "" {
+		t.Fatalf("NewEvent() = %+v", event)
+	}
+
+	payload, err := event.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Unmarshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != event.ID || decoded.Type != event.Type || !decoded.Time.Equal(event.Time) || decoded.TraceParent != event.TraceParent {
+		t.Errorf("Unmarshal() = %+v, want %+v", decoded, event)
+	}
+
+	var data struct{ Stock int }
+	if err := decoded.DecodeData(&data); err != nil || data.Stock != 5 {
+		t.Errorf("DecodeData() = %+v, %v", data, err)
+	}
+
+	// The consumer's spans join the producer's trace
+	if got := trace.SpanContextFromContext(decoded.ContextWithTrace(context.Background())); got.TraceID() != traceID || !got.IsRemote() {
+		t.Errorf("ContextWithTrace() span context = %+v", got)
+	}
+}
+
+func TestUnmarshalRejectsInvalidEnvelopes(t *testing.T) {
+	valid := map[string]any{"specversion": "1.0", "id": "1", "type": "product.created", "source": "business-service"}
+
+	tests := []struct {
+		name   string
+		change map[string]any
+		want   string
+	}{
+		{name: "newer major version", change: map[string]any{"specversion": "2.0"}, want: "unsupported event specversion"},
+		{name: "missing id", change: map[string]any{"id": ""}, want: "requires id, type and source"},
+		{name: "missing type", change: map[string]any{"type": nil}, want: "requires id, type and source"},
+		{name: "missing source", change: map[string]any{"source": ""}, want: "requires id, type and source"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope := map[string]any{}
+			for key, value := range valid {
+				envelope[key] = value
+			}
+			for key, value := range tt.change {
+				envelope[key] = value
+			}
+			payload, _ := json.Marshal(envelope)
+
+			if _, err := Unmarshal(payload); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Unmarshal() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Unmarshal([]byte(`{"specversion":"1.3","id":"1","type":"t","source":"s"}`)); err != nil {
+		t.Errorf("a newer minor version was rejected: %v", err)
+	}
+	if _, err := Unmarshal([]byte(`not json`)); err == nil {
+		t.Error("malformed JSON was accepted")
+	}
+}
+
+func TestMemoryBus(t *testing.T) {
+	bus := NewMemoryBus()
+	ctx := context.Background()
+	event := &Event{SpecVersion: SpecVersion, ID: "1", Type: "product.created", Source: "test", Time: time.Now()}
+
+	var received []string
+	record := func(name string) Handler {
+		return func(_ context.Context, e *Event) error {
+			received = append(received, name+":"+e.ID)
+			return nil
+		}
+	}
+	failing := errors.New("handler failed")
+
+	if err := bus.Subscribe(ctx, "business", record("first")); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Subscribe(ctx, "business", func(context.Context, *Event) error { return failing }); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Subscribe(ctx, "orders", record("other topic")); err != nil {
+		t.Fatal(err)
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	if err := bus.Subscribe(cancelled, "business", record("cancelled")); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	if err := bus.Publish(ctx, "business", event); !errors.Is(err, failing) {
+		t.Errorf("Publish() error = %v, want the handler's failure", err)
+	}
+	if strings.Join(received, ",") != "first:1" {
+		t.Errorf("received = %v, want only the active subscriber of the topic", received)
+	}
+
+	if err := bus.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish(ctx, "business", event); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish() after Close error = %v, want ErrClosed", err)
+	}
+	if err := bus.Subscribe(ctx, "business", record("late")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Subscribe() after Close error = %v, want ErrClosed", err)
+	}
+}
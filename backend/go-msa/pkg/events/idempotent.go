@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ProcessedEventsTableDDL creates the table Idempotent records handled events in.
+// No service subscribes to events yet, so no migration creates it; the first consumer adds it to its own
+// Flyway migrations before wrapping a handler with Idempotent.
+const ProcessedEventsTableDDL = `CREATE TABLE IF NOT EXISTS processed_events (
+    consumer VARCHAR(100) NOT NULL,
+    event_id VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (consumer, event_id)
+)`
+
+// TxBeginner is satisfied by *pgxpool.Pool and *pgx.Conn
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type txKey struct{}
+
+// TxFromContext returns the transaction Idempotent opened for the current event.
+// Handlers write through it so their changes commit atomically with the processed marker.
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// Idempotent wraps handler so each event ID is applied at most once per consumer.
+// The event is marked as processed in the same transaction the handler runs in, and a handler error
+// rolls both back. That only leaves the event free to be applied by a later delivery: whether one happens
+// is up to the backend, and PostgresSubscriber logs the failure and never redelivers.
+func Idempotent(db TxBeginner, consumer string, handler Handler) Handler {
+	return func(ctx context.Context, event *Event) error {
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		tag, err := tx.Exec(ctx,
+			`INSERT INTO processed_events (consumer, event_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			consumer, event.ID)
+		if err != nil {
+			return fmt.Errorf("failed to record processed event: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			// Already handled by this consumer
+			return nil
+		}
+
+		if err := handler(context.WithValue(ctx, txKey{}, tx), event); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit processed event: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// processedStore stands in for the processed_events table
+type processedStore struct {
+	committed map[string]bool
+}
+
+func (s *processedStore) Begin(context.Context) (pgx.Tx, error) {
+	return &processedTx{store: s}, nil
+}
+
+// processedTx implements the parts of pgx.Tx that Idempotent uses; the embedded nil Tx panics on anything else
+type processedTx struct {
+	pgx.Tx
+	store   *processedStore
+	pending []string
+}
+
+func (tx *processedTx) Exec(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+	key := args[0].(string) + "/" + args[1].(string)
+	if tx.store.committed[key] {
+		return pgconn.NewCommandTag("INSERT 0 0"), nil
+	}
+	tx.pending = append(tx.pending, key)
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+
+func (tx *processedTx) Commit(context.Context) error {
+	for _, key := range tx.pending {
+		tx.store.committed[key] = true
+	}
+	return nil
+}
+
+func (tx *processedTx) Rollback(context.Context) error {
+	return nil
+}
+
+func TestIdempotent(t *testing.T) {
+	store := &processedStore{committed: map[string]bool{}}
+	ctx := context.Background()
+	event := &Event{ID: "event-1", Type: "product.created"}
+
+	calls := 0
+	fail := errors.New("handler failed")
+	var result error
+	handler := func(ctx context.Context, e *Event) error {
+		calls++
+		if _, ok := TxFromContext(ctx); !ok {
+			t.Error("handler ran without the transaction")
+		}
+		return result
+	}
+	orders := Idempotent(store, "orders", handler)
+
+	// A failed attempt leaves the event unprocessed
+	result = fail
+	if err := orders(ctx, event); !errors.Is(err, fail) {
+		t.Errorf("error = %v, want the handler's failure", err)
+	}
+	if store.committed["orders/event-1"] {
+		t.Error("a failed event was marked as processed")
+	}
+
+	result = nil
+	for i := 0; i < 2; i++ {
+		if err := orders(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want once after the failure and never for the duplicate", calls)
+	}
+
+	// Each consumer keeps its own record
+	if err := Idempotent(store, "billing", handler)(ctx, event); err != nil || calls != 3 {
+		t.Errorf("another consumer: error = %v, calls = %d, want the event applied", err, calls)
+	}
+}
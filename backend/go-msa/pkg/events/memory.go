@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when publishing to or subscribing on a closed bus
+var ErrClosed = errors.New("events: bus closed")
+
+// MemoryBus is an in-process Publisher and Subscriber intended for tests.
+// Publish calls every handler of the topic synchronously and returns their joined errors.
+type MemoryBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]subscription
+	closed   bool
+}
+
+type subscription struct {
+	ctx     context.Context
+	handler Handler
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		handlers: make(map[string][]subscription),
+	}
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, topic string, event *Event) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	subs := append([]subscription{}, b.handlers[topic]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if sub.ctx.Err() != nil {
+			continue
+		}
+		if err := sub.handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *MemoryBus) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	b.handlers[topic] = append(b.handlers[topic], subscription{ctx: ctx, handler: handler})
+	return nil
+}
+
+func (b *MemoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.handlers = make(map[string][]subscription)
+	return nil
+}
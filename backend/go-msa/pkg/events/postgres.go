@@ -0,0 +1,178 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"pkg/logger"
+)
+
+// maxNotifyPayload is the largest payload Postgres NOTIFY accepts with the default build
+const maxNotifyPayload = 8000
+
+// ErrPayloadTooLarge is returned when an encoded event does not fit in a NOTIFY payload
+var ErrPayloadTooLarge = errors.New("events: payload exceeds NOTIFY limit")
+
+// DBTX is satisfied by *pgxpool.Pool, *pgx.Conn and pgx.Tx
+type DBTX interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// PostgresPublisher publishes events with pg_notify.
+// Publishing through a pgx.Tx defers delivery until the transaction commits,
+// and nothing is delivered if it rolls back.
+type PostgresPublisher struct {
+	db DBTX
+}
+
+func NewPostgresPublisher(db DBTX) *PostgresPublisher {
+	return &PostgresPublisher{db: db}
+}
+
+func (p *PostgresPublisher) Publish(ctx context.Context, topic string, event *Event) error {
+	payload, err := event.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if len(payload) >= maxNotifyPayload {
+		return fmt.Errorf("%w: %s event is %d bytes", ErrPayloadTooLarge, event.Type, len(payload))
+	}
+
+	if _, err := p.db.Exec(ctx, "SELECT pg_notify($1, $2)", topic, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify %s: %w", topic, err)
+	}
+	return nil
+}
+
+func (p *PostgresPublisher) Close() error {
+	return nil
+}
+
+// PostgresSubscriber receives events with LISTEN on a dedicated connection per topic.
+// NOTIFY is fire-and-forget: events sent while a listener is reconnecting are lost, and an event
+// whose handler fails is logged and dropped rather than redelivered, so it suits cache invalidation
+// and notifications rather than state transfer.
+// Publisher and subscriber must be connected to the same database.
+type PostgresSubscriber struct {
+	pool          *pgxpool.Pool
+	retryInterval time.Duration
+	log           logger.Logger
+
+	mu      sync.Mutex
+	cancels []context.CancelFunc
+	wg      sync.WaitGroup
+	closed  bool
+}
+
+func NewPostgresSubscriber(pool *pgxpool.Pool, retryInterval time.Duration, log logger.Logger) *PostgresSubscriber {
+	return &PostgresSubscriber{
+		pool:          pool,
+		retryInterval: retryInterval,
+		log:           log,
+	}
+}
+
+func (s *PostgresSubscriber) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+
+	conn, err := s.listen(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	s.cancels = append(s.cancels, cancel)
+	s.wg.Add(1)
+	go s.run(listenCtx, conn, topic, handler)
+	return nil
+}
+
+// Close stops every listener and waits for in-flight handlers to return
+func (s *PostgresSubscriber) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for _, cancel := range s.cancels {
+		cancel()
+	}
+	s.cancels = nil
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+// listen takes a connection out of the pool, since LISTEN state must not leak back into it
+func (s *PostgresSubscriber) listen(ctx context.Context, topic string) (*pgx.Conn, error) {
+	pooled, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	conn := pooled.Hijack()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{topic}.Sanitize()); err != nil {
+		conn.Close(context.Background())
+		return nil, fmt.Errorf("failed to listen on %s: %w", topic, err)
+	}
+	return conn, nil
+}
+
+func (s *PostgresSubscriber) run(ctx context.Context, conn *pgx.Conn, topic string, handler Handler) {
+	defer s.wg.Done()
+	defer func() {
+		if conn != nil {
+			conn.Close(context.Background())
+		}
+	}()
+
+	for ctx.Err() == nil {
+		if conn == nil {
+			var err error
+			if conn, err = s.listen(ctx, topic); err != nil {
+				s.log.Warn("Reconnecting event listener", "topic", topic, "error", err)
+				s.wait(ctx)
+				continue
+			}
+		}
+
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.log.Warn("Lost event listener connection", "topic", topic, "error", err)
+			conn.Close(context.Background())
+			conn = nil
+			continue
+		}
+
+		event, err := Unmarshal([]byte(notification.Payload))
+		if err != nil {
+			s.log.Error("Dropping malformed event", "topic", topic, "error", err)
+			continue
+		}
+		if err := handler(event.ContextWithTrace(ctx), event); err != nil {
+			s.log.ErrorContext(ctx, "Failed to handle event", "event_type", event.Type, "event_id", event.ID, "error", err)
+		}
+	}
+}
+
+func (s *PostgresSubscriber) wait(ctx context.Context) {
+	timer := time.NewTimer(s.retryInterval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
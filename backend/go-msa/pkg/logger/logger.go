@@ -1,60 +1,172 @@
 package logger
 
 import (
-	"log"
+	"context"
+	"io"
+	"log/slog"
 	"os"
+	"runtime"
 	"strings"
+	"time"
 )
 
+// LevelFatal is logged by Fatal before the process exits
+const LevelFatal = slog.Level(12)
+
+// Logger is a leveled, structured logger.
+// args are alternating key/value pairs, as with log/slog.
 type Logger interface {
-	Debug(msg string, args ...interface{})
-	Info(msg string, args ...interface{})
-	Warn(msg string, args ...interface{})
-	Error(msg string, args ...interface{})
-	Fatal(msg string, args ...interface{})
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+	Fatal(msg string, args ...any)
+	DebugContext(ctx context.Context, msg string, args ...any)
+	InfoContext(ctx context.Context, msg string, args ...any)
+	WarnContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+	With(key string, value any) Logger
+}
+
+type SlogLogger struct {
+	logger *slog.Logger
 }
 
-type SimpleLogger struct {
-	debugLogger *log.Logger
-	infoLogger  *log.Logger
-	warnLogger  *log.Logger
-	errorLogger *log.Logger
-	fatalLogger *log.Logger
-	level       string
+// New creates a logger for service writing info and below to stdout and errors to stderr.
+// format is "json" or "text"; level is one of debug, info, warn, error, fatal.
+func New(service, level, format string) Logger {
+	return NewWithWriters(service, level, format, os.Stdout, os.Stderr)
 }
 
-func New(service string, level string) Logger {
-	prefix := "[" + strings.ToUpper(service) + "] "
+// NewWithWriters is New with explicit destinations for regular and error output
+func NewWithWriters(service, level, format string, out, errOut io.Writer) Logger {
+	opts := &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       ParseLevel(level),
+		ReplaceAttr: replaceLevel,
+	}
+
+	handler := &contextHandler{
+		Handler: &splitHandler{
+			out: newHandler(format, out, opts),
+			err: newHandler(format, errOut, opts),
+		},
+	}
 
-	return &SimpleLogger{
-		debugLogger: log.New(os.Stdout, prefix+"DEBUG: ", log.Ldate|log.Ltime|log.Lshortfile),
-		infoLogger:  log.New(os.Stdout, prefix+"INFO: ", log.Ldate|log.Ltime|log.Lshortfile),
-		warnLogger:  log.New(os.Stdout, prefix+"WARN: ", log.Ldate|log.Ltime|log.Lshortfile),
-		errorLogger: log.New(os.Stderr, prefix+"ERROR: ", log.Ldate|log.Ltime|log.Lshortfile),
-		fatalLogger: log.New(os.Stdout, prefix+"FATAL: ", log.Ldate|log.Ltime|log.Lshortfile),
-		level:       level,
+	return &SlogLogger{
+		logger: slog.New(handler).With("service", service),
 	}
 }
 
-func (l *SimpleLogger) Info(msg string, args ...interface{}) {
-	l.infoLogger.Printf(msg, args...)
+// ParseLevel converts a level name to a slog level, defaulting to info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	case "fatal":
+		return LevelFatal
+	default:
+		return slog.LevelInfo
+	}
 }
 
-func (l *SimpleLogger) Error(msg string, args ...interface{}) {
-	l.errorLogger.Printf(msg, args...)
+func (l *SlogLogger) Debug(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelDebug, msg, args...)
 }
 
-func (l *SimpleLogger) Debug(msg string, args ...interface{}) {
-	if l.level == "debug" {
-		l.debugLogger.Printf(msg, args...)
-	}
+func (l *SlogLogger) Info(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelInfo, msg, args...)
 }
 
-func (l *SimpleLogger) Warn(msg string, args ...interface{}) {
-	l.warnLogger.Printf(msg, args...)
+func (l *SlogLogger) Warn(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelWarn, msg, args...)
 }
 
-func (l *SimpleLogger) Fatal(msg string, args ...interface{}) {
-	l.fatalLogger.Printf(msg, args...)
+func (l *SlogLogger) Error(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelError, msg, args...)
+}
+
+func (l *SlogLogger) Fatal(msg string, args ...any) {
+	l.log(context.Background(), LevelFatal, msg, args...)
 	os.Exit(1)
 }
+
+func (l *SlogLogger) DebugContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelDebug, msg, args...)
+}
+
+func (l *SlogLogger) InfoContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelInfo, msg, args...)
+}
+
+func (l *SlogLogger) WarnContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelWarn, msg, args...)
+}
+
+func (l *SlogLogger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelError, msg, args...)
+}
+
+// With returns a child logger that adds key=value to every record
+func (l *SlogLogger) With(key string, value any) Logger {
+	return &SlogLogger{logger: l.logger.With(key, value)}
+}
+
+// log records the caller of the exported method as the source, not this wrapper
+func (l *SlogLogger) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	record := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	record.Add(args...)
+	_ = l.logger.Handler().Handle(ctx, record)
+}
+
+func newHandler(format string, w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	if strings.ToLower(format) == "text" {
+		return slog.NewTextHandler(w, opts)
+	}
+	return slog.NewJSONHandler(w, opts)
+}
+
+// replaceLevel names LevelFatal, which slog would otherwise print as ERROR+4
+func replaceLevel(_ []string, attr slog.Attr) slog.Attr {
+	if attr.Key == slog.LevelKey {
+		if level, ok := attr.Value.Any().(slog.Level); ok && level >= LevelFatal {
+			attr.Value = slog.StringValue("FATAL")
+		}
+	}
+	return attr
+}
+
+// splitHandler sends error and fatal records to err and everything else to out
+type splitHandler struct {
+	out slog.Handler
+	err slog.Handler
+}
+
+func (h *splitHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.out.Enabled(ctx, level)
+}
+
+func (h *splitHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelError {
+		return h.err.Handle(ctx, record)
+	}
+	return h.out.Handle(ctx, record)
+}
+
+func (h *splitHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &splitHandler{out: h.out.WithAttrs(attrs), err: h.err.WithAttrs(attrs)}
+}
+
+func (h *splitHandler) WithGroup(name string) slog.Handler {
+	return &splitHandler{out: h.out.WithGroup(name), err: h.err.WithGroup(name)}
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// UnmatchedRoute labels requests no ServeMux pattern matched, keeping raw paths out of label values
+const UnmatchedRoute = "unmatched"
+
+// NewRegistry creates a registry preloaded with Go runtime and process collectors
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// HTTPMetrics holds the RED (rate, errors, duration) metrics of an HTTP server
+type HTTPMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewHTTPMetrics creates the RED metrics and registers them with reg
+func NewHTTPMetrics(reg prometheus.Registerer) *HTTPMetrics {
+	labels := []string{"route", "method", "status"}
+
+	m := &HTTPMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total HTTP requests handled.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_request_errors_total",
+			Help: "HTTP requests that ended in a 5xx response.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+	}
+
+	reg.MustRegister(m.requests, m.errors, m.duration)
+	return m
+}
+
+// Observe records one finished request
+func (m *HTTPMetrics) Observe(route, method string, status int, duration time.Duration) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+	statusLabel := strconv.Itoa(status)
+
+	m.requests.WithLabelValues(route, method, statusLabel).Inc()
+	m.duration.WithLabelValues(route, method, statusLabel).Observe(duration.Seconds())
+	if status >= 500 {
+		m.errors.WithLabelValues(route, method, statusLabel).Inc()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"pkg/common_handler"
+)
+
+// BodyLimitOverride gives the requests matching Pattern, a ServeMux pattern such as "POST /{version}/business/products/imports",
+// their own body limit, typically for uploads whose handler enforces a larger limit of its own.
+// A non-positive MaxBytes leaves matching requests unlimited.
+type BodyLimitOverride struct {
+	Pattern  string
+	MaxBytes int64
+}
+
+// MaxBodySizeMiddleware rejects requests whose declared Content-Length exceeds maxBytes with 413
+// and caps the body reader for chunked uploads. A non-positive maxBytes disables the middleware
+// for requests that no override matches.
+func MaxBodySizeMiddleware(maxBytes int64, overrides ...BodyLimitOverride) Middleware {
+	return func(next http.Handler) http.Handler {
+		if maxBytes <= 0 && len(overrides) == 0 {
+			return next
+		}
+
+		patterns := make([]string, len(overrides))
+		limits := make(map[string]int64, len(overrides))
+		for i, override := range overrides {
+			patterns[i] = override.Pattern
+			limits[override.Pattern] = override.MaxBytes
+		}
+		match := newPatternMatcher(patterns)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			maxBytes := maxBytes
+			if pattern, ok := match(r); ok {
+				maxBytes = limits[pattern]
+			}
+			if maxBytes <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.ContentLength > maxBytes {
+				common_handler.WriteError(w, r, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE",
+					fmt.Sprintf("Request body must not exceed %d bytes", maxBytes))
+				return
+			}
+
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodySizeMiddlewareOverrides(t *testing.T) {
+	handler := MaxBodySizeMiddleware(4,
+		BodyLimitOverride{Pattern: "POST /{version}/business/products/imports", MaxBytes: 16},
+		BodyLimitOverride{Pattern: "POST /{version}/business/products/uploads", MaxBytes: 0},
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		}
+	}))
+
+	tests := []struct {
+		name, path, body string
+		chunked          bool
+		want             int
+	}{
+		{name: "within the default limit", path: "/v1/business/products", body: "1234", want: http.StatusOK},
+		{name: "over the default limit", path: "/v1/business/products", body: "12345", want: http.StatusRequestEntityTooLarge},
+		{name: "chunked over the default limit", path: "/v1/business/products", body: "12345", chunked: true, want: http.StatusRequestEntityTooLarge},
+		{name: "import within its limit", path: "/v2/business/products/imports", body: "1234567890", want: http.StatusOK},
+		{name: "import over its limit", path: "/v1/business/products/imports", body: strings.Repeat("1", 17), chunked: true, want: http.StatusRequestEntityTooLarge},
+		{name: "unlimited upload", path: "/v1/business/products/uploads", body: strings.Repeat("1", 1024), want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				r.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
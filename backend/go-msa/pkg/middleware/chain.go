@@ -0,0 +1,19 @@
+package middleware
+
+import "net/http"
+
+// Middleware wraps an http.Handler with additional behaviour
+type Middleware func(http.Handler) http.Handler
+
+// Chain composes middlewares so the first one listed is the outermost:
+// Chain(a, b, c)(h) is equivalent to a(b(c(h))). Nil entries are skipped.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			if middlewares[i] != nil {
+				next = middlewares[i](next)
+			}
+		}
+		return next
+	}
+}
@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// minCompressSize skips compression for responses known to be smaller than this many bytes
+const minCompressSize = 1024
+
+var (
+	gzipPool = sync.Pool{New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	brotliPool = sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(io.Discard, brotli.DefaultCompression)
+	}}
+)
+
+// CompressionMiddleware compresses text and JSON responses with br or gzip, following Accept-Encoding.
+// Responses that already carry a Content-Encoding, such as proxied upstream responses, pass through unchanged.
+func CompressionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.Close()
+
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks br over gzip among the encodings the client accepts with a non-zero quality
+func negotiateEncoding(acceptEncoding string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil && value == 0 {
+				continue
+			}
+		}
+		accepted[name] = true
+	}
+
+	switch {
+	case accepted["br"]:
+		return "br"
+	case accepted["gzip"]:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+func isCompressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch {
+	case mediaType == "application/json",
+		mediaType == "application/xml",
+		mediaType == "application/javascript",
+		mediaType == "application/yaml",
+		mediaType == "application/x-ndjson",
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	default:
+		return false
+	}
+}
+
+// compressWriter decides on the first WriteHeader or Write whether to compress, based on the final headers
+type compressWriter struct {
+	http.ResponseWriter
+	encoding    string
+	encoder     io.WriteCloser
+	wroteHeader bool
+}
+
+func (cw *compressWriter) WriteHeader(statusCode int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+
+	h := cw.Header()
+	if cw.shouldCompress(statusCode, h) {
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", cw.encoding)
+		h.Add("Vary", "Accept-Encoding")
+		cw.encoder = cw.newEncoder()
+	}
+
+	cw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (cw *compressWriter) shouldCompress(statusCode int, h http.Header) bool {
+	if statusCode < http.StatusOK || statusCode == http.StatusNoContent || statusCode == http.StatusNotModified {
+		return false
+	}
+	if h.Get("Content-Encoding") != "" || !isCompressible(h.Get("Content-Type")) {
+		return false
+	}
+	if length, err := strconv.Atoi(h.Get("Content-Length")); err == nil && length < minCompressSize {
+		return false
+	}
+	return true
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.wroteHeader {
+		if cw.Header().Get("Content-Type") == "" {
+			cw.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.encoder != nil {
+		return cw.encoder.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush pushes buffered compressed bytes to the client so streaming responses keep working
+func (cw *compressWriter) Flush() {
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	_ = http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Close finishes the compressed stream and returns the encoder to its pool
+func (cw *compressWriter) Close() error {
+	if cw.encoder == nil {
+		return nil
+	}
+
+	err := cw.encoder.Close()
+	switch enc := cw.encoder.(type) {
+	case *gzip.Writer:
+		enc.Reset(io.Discard)
+		gzipPool.Put(enc)
+	case *brotli.Writer:
+		enc.Reset(io.Discard)
+		brotliPool.Put(enc)
+	}
+	cw.encoder = nil
+	return err
+}
+
+func (cw *compressWriter) newEncoder() io.WriteCloser {
+	if cw.encoding == "br" {
+		enc := brotliPool.Get().(*brotli.Writer)
+		enc.Reset(cw.ResponseWriter)
+		return enc
+	}
+	enc := gzipPool.Get().(*gzip.Writer)
+	enc.Reset(cw.ResponseWriter)
+	return enc
+}
@@ -1,46 +1,98 @@
 package middleware
 
 import (
+	"math/rand/v2"
 	"net/http"
+	"strings"
 	"time"
 
 	"pkg/logger"
+	"pkg/router"
 )
 
-// LoggingMiddleware logs HTTP requests
-func LoggingMiddleware(logger logger.Logger) func(http.Handler) http.Handler {
+// UserIDHeader carries the user ID the gateway authenticated
+const UserIDHeader = "X-Authenticated-User-ID"
+
+// AccessLogConfig controls which requests LoggingMiddleware records
+type AccessLogConfig struct {
+	// SkipPaths are not logged; an entry also covers the paths below it ("/health" skips "/health/ready")
+	SkipPaths []string
+	// SampleRate is the fraction of successful (< 400) requests logged; errors are always logged
+	SampleRate float64
+}
+
+// DefaultAccessLogConfig logs every request except health checks and metrics scrapes
+func DefaultAccessLogConfig() AccessLogConfig {
+	return AccessLogConfig{
+		SkipPaths:  []string{"/health", "/metrics"},
+		SampleRate: 1,
+	}
+}
+
+func (c AccessLogConfig) skip(path string) bool {
+	for _, prefix := range c.SkipPaths {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (c AccessLogConfig) sampled(status int) bool {
+	if status >= http.StatusBadRequest || c.SampleRate >= 1 {
+		return true
+	}
+	return c.SampleRate > 0 && rand.Float64() < c.SampleRate
+}
+
+// LoggingMiddleware writes an access log entry per request and makes the authenticated user
+// available to request-scoped logs
+func LoggingMiddleware(log logger.Logger, cfg AccessLogConfig) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path == "/health" {
+			if cfg.skip(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
 			start := time.Now()
 
-			// Create a response writer wrapper to capture status code
-			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			r = router.WithRoute(r)
+			ctx := r.Context()
+			if userID := r.Header.Get(UserIDHeader); userID != "" {
+				ctx = logger.ContextWithUserID(ctx, userID)
+			}
+
+			rw := NewResponseWriter(w)
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			if !cfg.sampled(rw.Status()) {
+				return
+			}
+
+			// At the gateway the user is only known once authentication has run further down the chain
+			if logger.UserIDFromContext(ctx) == "" {
+				if userID := r.Header.Get(UserIDHeader); userID != "" {
+					ctx = logger.ContextWithUserID(ctx, userID)
+				}
+			}
 
-			next.ServeHTTP(rw, r)
+			clientIP := ClientIPFromContext(ctx)
+			if clientIP == "" {
+				clientIP = r.RemoteAddr
+			}
 
-			duration := time.Since(start)
-			logger.Info("HTTP %s %s %d %v %s",
-				r.Method,
-				r.URL.Path,
-				rw.statusCode,
-				duration,
-				r.RemoteAddr,
+			log.InfoContext(ctx, "HTTP request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"route", router.Route(r),
+				"status", rw.Status(),
+				"bytes", rw.BytesWritten(),
+				"duration", time.Since(start),
+				"client_ip", clientIP,
+				"user_agent", r.UserAgent(),
 			)
 		})
 	}
 }
-
-type responseWriter struct {
-	http.ResponseWriter
-	statusCode int
-}
-
-func (rw *responseWriter) WriteHeader(code int) {
-	rw.statusCode = code
-	rw.ResponseWriter.WriteHeader(code)
-}
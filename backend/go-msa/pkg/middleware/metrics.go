@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"pkg/metrics"
+	"pkg/router"
+)
+
+// MetricsMiddleware records RED metrics per route pattern.
+// The pattern is read through router.Route, so router.RecordRoute must wrap the ServeMux.
+func MetricsMiddleware(m *metrics.HTTPMetrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/health") || r.URL.Path == "/metrics" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			r = router.WithRoute(r)
+			rw := NewResponseWriter(w)
+
+			next.ServeHTTP(rw, r)
+
+			m.Observe(router.Route(r), r.Method, rw.Status(), time.Since(start))
+		})
+	}
+}
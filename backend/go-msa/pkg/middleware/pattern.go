@@ -0,0 +1,21 @@
+package middleware
+
+import "net/http"
+
+// newPatternMatcher returns a function reporting which of the ServeMux patterns, if any, a request matches
+func newPatternMatcher(patterns []string) func(r *http.Request) (string, bool) {
+	if len(patterns) == 0 {
+		return func(*http.Request) (string, bool) { return "", false }
+	}
+
+	mux := http.NewServeMux()
+	registered := make(map[string]bool, len(patterns))
+	for _, pattern := range patterns {
+		mux.Handle(pattern, http.NotFoundHandler())
+		registered[pattern] = true
+	}
+	return func(r *http.Request) (string, bool) {
+		_, pattern := mux.Handler(r)
+		return pattern, registered[pattern]
+	}
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ForwardedForHeader lists the client and each proxy a request passed through
+const ForwardedForHeader = "X-Forwarded-For"
+
+type clientIPKey struct{}
+
+type trustedProxyKey struct{}
+
+// ClientIPFromContext returns the client IP resolved by RealIPMiddleware
+func ClientIPFromContext(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// FromTrustedProxy reports whether RealIPMiddleware found the request's peer to be a trusted proxy,
+// meaning its X-Forwarded-* headers can be believed
+func FromTrustedProxy(ctx context.Context) bool {
+	trusted, _ := ctx.Value(trustedProxyKey{}).(bool)
+	return trusted
+}
+
+// ParseTrustedProxies parses IPs and CIDR ranges of proxies allowed to set X-Forwarded-For
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// RealIPMiddleware resolves the client IP and stores it in the request context, along with whether the peer
+// is a trusted proxy (see FromTrustedProxy).
+// X-Forwarded-For is only honoured when the peer is a trusted proxy; it is then walked from the right,
+// skipping trusted hops, so a client cannot spoof its address by prepending entries.
+func RealIPMiddleware(trusted []netip.Prefix) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, trustedPeer := clientIP(r, trusted)
+			ctx := context.WithValue(r.Context(), clientIPKey{}, ip)
+			ctx = context.WithValue(ctx, trustedProxyKey{}, trustedPeer)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// clientIP returns the client IP and whether the peer is a trusted proxy
+func clientIP(r *http.Request, trusted []netip.Prefix) (string, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	peer, err := netip.ParseAddr(host)
+	if err != nil || !isTrusted(peer, trusted) {
+		return host, false
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values(ForwardedForHeader), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		if !isTrusted(addr, trusted) {
+			return addr.Unmap().String(), true
+		}
+		host = addr.Unmap().String()
+	}
+	return host, true
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
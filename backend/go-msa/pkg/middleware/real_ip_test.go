@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRealIPMiddleware(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		remoteAddr  string
+		forwarded   string
+		wantIP      string
+		wantTrusted bool
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.7:4000", wantIP: "203.0.113.7"},
+		{name: "untrusted peer cannot forward", remoteAddr: "203.0.113.7:4000", forwarded: "198.51.100.1", wantIP: "203.0.113.7"},
+		{name: "trusted proxy", remoteAddr: "10.0.0.5:4000", forwarded: "198.51.100.1", wantIP: "198.51.100.1", wantTrusted: true},
+		{name: "spoofed entries are skipped", remoteAddr: "10.0.0.5:4000", forwarded: "1.2.3.4, 198.51.100.1, 192.168.1.1", wantIP: "198.51.100.1", wantTrusted: true},
+		{name: "only trusted hops", remoteAddr: "10.0.0.5:4000", forwarded: "10.0.0.9", wantIP: "10.0.0.9", wantTrusted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIP string
+			var gotTrusted bool
+			handler := RealIPMiddleware(trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				gotIP = ClientIPFromContext(r.Context())
+				gotTrusted = FromTrustedProxy(r.Context())
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				r.Header.Set(ForwardedForHeader, tt.forwarded)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if gotIP != tt.wantIP || gotTrusted != tt.wantTrusted {
+				t.Errorf("got (%s, %v), want (%s, %v)", gotIP, gotTrusted, tt.wantIP, tt.wantTrusted)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxiesRejectsInvalidEntries(t *testing.T) {
+	if _, err := ParseTrustedProxies([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("ParseTrustedProxies() accepted an invalid CIDR")
+	}
+	if _, err := ParseTrustedProxies([]string{"proxy.internal"}); err == nil {
+		t.Error("ParseTrustedProxies() accepted a hostname")
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"pkg/common_handler"
+	"pkg/logger"
+)
+
+// RecoveryMiddleware turns a handler panic into a logged error with its stack trace and a JSON 500 response.
+// http.ErrAbortHandler is re-raised so the server still aborts the connection as intended.
+func RecoveryMiddleware(log logger.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				log.ErrorContext(r.Context(), "Panic recovered",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"panic", recovered,
+					"stack", string(debug.Stack()),
+				)
+
+				// Connection upgrades have no HTTP response to write
+				if r.Header.Get("Connection") == "Upgrade" {
+					return
+				}
+				common_handler.WriteError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
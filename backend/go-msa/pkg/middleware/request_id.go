@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"pkg/logger"
+)
+
+// RequestIDHeader correlates a request across the gateway and the services behind it
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied IDs so they cannot bloat logs
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware accepts a well-formed incoming X-Request-ID or generates one,
+// stores it in the request context for logging, forwards it on the request and echoes it in the response
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !ValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		r.Header.Set(RequestIDHeader, requestID)
+		w.Header().Set(RequestIDHeader, requestID)
+
+		next.ServeHTTP(w, r.WithContext(logger.ContextWithRequestID(r.Context(), requestID)))
+	})
+}
+
+// ValidRequestID allows visible ASCII only, keeping IDs safe to log and to copy into headers or metadata
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
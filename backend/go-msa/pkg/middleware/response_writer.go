@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+// ResponseWriter records the status code and body size of a response.
+// Flush and Hijack delegate through http.ResponseController and Unwrap exposes the wrapped writer,
+// so streaming responses and connection upgrades keep working through it.
+type ResponseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	bytes       int64
+	wroteHeader bool
+}
+
+// NewResponseWriter wraps w, reusing w when it is already a *ResponseWriter
+func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	if rw, ok := w.(*ResponseWriter); ok {
+		return rw
+	}
+	return &ResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+}
+
+// Status returns the response status code, 200 when the handler never set one
+func (rw *ResponseWriter) Status() int {
+	return rw.statusCode
+}
+
+// BytesWritten returns the number of body bytes written
+func (rw *ResponseWriter) BytesWritten() int64 {
+	return rw.bytes
+}
+
+func (rw *ResponseWriter) WriteHeader(code int) {
+	// Informational responses other than 101 precede the final status
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		rw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if rw.wroteHeader {
+		return
+	}
+	rw.statusCode = code
+	rw.wroteHeader = true
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *ResponseWriter) Write(p []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher
+func (rw *ResponseWriter) Flush() {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	_ = http.NewResponseController(rw.ResponseWriter).Flush()
+}
+
+// Hijack implements http.Hijacker; a hijacked connection is recorded as 101 Switching Protocols
+func (rw *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil && !rw.wroteHeader {
+		rw.statusCode = http.StatusSwitchingProtocols
+		rw.wroteHeader = true
+	}
+	return conn, buf, err
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSecurityHeadersMiddlewareHSTS(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := Chain(RealIPMiddleware(trusted), SecurityHeadersMiddleware)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		proto      string
+		want       bool
+	}{
+		{name: "trusted proxy over https", remoteAddr: "10.0.0.5:4000", proto: "https", want: true},
+		{name: "trusted proxy over http", remoteAddr: "10.0.0.5:4000", proto: "http", want: false},
+		{name: "untrusted peer claiming https", remoteAddr: "203.0.113.7:4000", proto: "https", want: false},
+		{name: "untrusted peer without header", remoteAddr: "203.0.113.7:4000", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.proto != "" {
+				r.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Strict-Transport-Security") != ""; got != tt.want {
+				t.Errorf("HSTS sent = %v, want %v", got, tt.want)
+			}
+			if w.Header().Get("X-Content-Type-Options") != "nosniff" {
+				t.Error("X-Content-Type-Options not set")
+			}
+		})
+	}
+}
+
+func TestSecurityHeadersMiddlewareIgnoresForwardedProtoWithoutRealIP(t *testing.T) {
+	handler := SecurityHeadersMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	if w.Header().Get("Strict-Transport-Security") != "" {
+		t.Error("HSTS sent for an unverified X-Forwarded-Proto")
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// TimeoutOverride gives the requests matching Pattern, a ServeMux pattern such as "GET /{version}/business/products/export",
+// their own timeout. The connection's write deadline moves with it, since the server's WriteTimeout would otherwise
+// cut off a long streamed response. A non-positive Timeout leaves matching requests unbounded.
+type TimeoutOverride struct {
+	Pattern string
+	Timeout time.Duration
+}
+
+// TimeoutMiddleware bounds the request context by timeout. Handlers and the queries they run observe
+// the deadline through r.Context(); the response itself is left to the handler so streaming still works.
+// A non-positive timeout disables the middleware for requests that no override matches.
+func TimeoutMiddleware(timeout time.Duration, overrides ...TimeoutOverride) Middleware {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 && len(overrides) == 0 {
+			return next
+		}
+
+		patterns := make([]string, len(overrides))
+		timeouts := make(map[string]time.Duration, len(overrides))
+		for i, override := range overrides {
+			patterns[i] = override.Pattern
+			timeouts[override.Pattern] = override.Timeout
+		}
+		match := newPatternMatcher(patterns)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := timeout
+			if pattern, ok := match(r); ok {
+				timeout = timeouts[pattern]
+				// The zero deadline clears the server's WriteTimeout
+				var deadline time.Time
+				if timeout > 0 {
+					deadline = time.Now().Add(timeout)
+				}
+				_ = http.NewResponseController(w).SetWriteDeadline(deadline)
+			}
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
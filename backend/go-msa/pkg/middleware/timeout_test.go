@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutMiddlewareOverrides(t *testing.T) {
+	var deadline time.Time
+	var bounded bool
+	handler := TimeoutMiddleware(time.Second,
+		TimeoutOverride{Pattern: "GET /{version}/business/products/export", Timeout: time.Hour},
+		TimeoutOverride{Pattern: "GET /{version}/business/products/stream", Timeout: 0},
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, bounded = r.Context().Deadline()
+	}))
+
+	tests := []struct {
+		method, path string
+		want         time.Duration
+	}{
+		{method: http.MethodGet, path: "/v1/business/products", want: time.Second},
+		{method: http.MethodGet, path: "/v2/business/products/export", want: time.Hour},
+		{method: http.MethodPost, path: "/v1/business/products/export", want: time.Second},
+		{method: http.MethodGet, path: "/v1/business/products/stream", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			if tt.want == 0 {
+				if bounded {
+					t.Errorf("deadline = %v, want none", deadline)
+				}
+				return
+			}
+			if remaining := time.Until(deadline); !bounded || remaining > tt.want || remaining < tt.want/2 {
+				t.Errorf("deadline in %v, want %v", remaining, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddlewareExtendsWriteDeadline(t *testing.T) {
+	handler := TimeoutMiddleware(time.Second,
+		TimeoutOverride{Pattern: "GET /export", Timeout: time.Minute},
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("done"))
+	}))
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.WriteTimeout = 50 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/export")
+	if err != nil {
+		t.Fatalf("GET /export error = %v", err)
+	}
+	defer resp.Body.Close()
+	if body, err := io.ReadAll(resp.Body); err != nil || string(body) != "done" {
+		t.Errorf("body = %q, error = %v", body, err)
+	}
+
+	if resp, err := http.Get(server.URL + "/other"); err == nil {
+		resp.Body.Close()
+		t.Error("GET /other outlived the server's WriteTimeout")
+	}
+}
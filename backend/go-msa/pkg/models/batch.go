@@ -0,0 +1,19 @@
+package models
+
+// BatchResult represents the outcome of a bulk operation, returned as APIResponse data
+type BatchResult struct {
+	Mode      string      `json:"mode"`
+	Total     int         `json:"total"`
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+	Items     []BatchItem `json:"items"`
+}
+
+// BatchItem represents the outcome of a single item, in request order
+type BatchItem struct {
+	Index  int         `json:"index"`
+	ID     string      `json:"id,omitempty"`
+	Status string      `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  *APIError   `json:"error,omitempty"`
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type for RFC 9457 problem details
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeDefault is used when a problem has no more specific type URI
+const ProblemTypeDefault = "about:blank"
+
+// FieldError describes a single invalid field in a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ProblemDetails represents an RFC 9457 problem details response
+type ProblemDetails struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// NewProblemDetails creates a problem details response for status
+func NewProblemDetails(status int, detail, instance string) ProblemDetails {
+	return ProblemDetails{
+		Type:       ProblemTypeDefault,
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     detail,
+		Instance:   instance,
+		Extensions: make(map[string]interface{}),
+	}
+}
+
+// MarshalJSON flattens extension members next to the standard members.
+// Extensions cannot override the standard members.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	} else {
+		delete(members, "detail")
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	} else {
+		delete(members, "instance")
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON splits standard members from extension members
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	standard := map[string]interface{}{
+		"type":     &p.Type,
+		"title":    &p.Title,
+		"status":   &p.Status,
+		"detail":   &p.Detail,
+		"instance": &p.Instance,
+	}
+
+	p.Extensions = make(map[string]interface{})
+	for key, raw := range members {
+		if target, ok := standard[key]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		p.Extensions[key] = value
+	}
+
+	if p.Type == "" {
+		p.Type = ProblemTypeDefault
+	}
+
+	return nil
+}
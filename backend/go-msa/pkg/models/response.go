@@ -7,14 +7,16 @@ type APIResponse struct {
 	Success   bool        `json:"success"`
 	Data      interface{} `json:"data,omitempty"`
 	Error     *APIError   `json:"error,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
 	Timestamp time.Time   `json:"timestamp"`
 }
 
 // APIError represents an error in API response
 type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Details string `json:"details,omitempty"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details string       `json:"details,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
 }
 
 // HealthResponse represents health check response
@@ -25,6 +27,29 @@ type HealthResponse struct {
 	Version   string    `json:"version,omitempty"`
 }
 
+// Health statuses reported by readiness checks
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// CheckResult represents the outcome of a single readiness check
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadinessResponse represents readiness check response
+type ReadinessResponse struct {
+	Status       string                 `json:"status"`
+	Service      string                 `json:"service"`
+	Timestamp    time.Time              `json:"timestamp"`
+	Version      string                 `json:"version,omitempty"`
+	ShuttingDown bool                   `json:"shutting_down,omitempty"`
+	Checks       map[string]CheckResult `json:"checks"`
+}
+
 // NewSuccessResponse creates a successful API response
 func NewSuccessResponse(data interface{}) APIResponse {
 	return APIResponse{
@@ -55,4 +80,21 @@ func NewHealthResponse(service, version string) HealthResponse {
 		Timestamp: time.Now(),
 		Version:   version,
 	}
+}
+
+// NewReadinessResponse creates a readiness check response
+func NewReadinessResponse(service, version string, ready, shuttingDown bool, checks map[string]CheckResult) ReadinessResponse {
+	status := "ready"
+	if !ready {
+		status = "not_ready"
+	}
+
+	return ReadinessResponse{
+		Status:       status,
+		Service:      service,
+		Timestamp:    time.Now(),
+		Version:      version,
+		ShuttingDown: shuttingDown,
+		Checks:       checks,
+	}
 }
\ No newline at end of file
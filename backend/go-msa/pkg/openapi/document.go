@@ -0,0 +1,154 @@
+package openapi
+
+// Version 생성하는 문서의 OpenAPI 버전
+const Version = "3.0.3"
+
+// BearerAuth 게이트웨이가 검증하는 Bearer 토큰 보안 스킴 이름
+const BearerAuth = "bearerAuth"
+
+// Document OpenAPI 3 문서 (필요한 필드만 정의)
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem 소문자 HTTP 메서드별 오퍼레이션
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Parameter path/query/header 파라미터
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// SecurityRequirement 스킴 이름별 스코프. 빈 요구사항 목록({})은 인증 없음
+type SecurityRequirement map[string][]string
+
+// Schema JSON 스키마 (OpenAPI 3.0 부분집합)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// walk 스키마와 하위 스키마를 깊이 우선으로 방문
+func (s *Schema) walk(visit func(*Schema)) {
+	if s == nil {
+		return
+	}
+	visit(s)
+	s.Items.walk(visit)
+	s.AdditionalProperties.walk(visit)
+	for _, property := range s.Properties {
+		property.walk(visit)
+	}
+}
+
+// walkSchemas 문서 안의 모든 스키마 방문
+func (d *Document) walkSchemas(visit func(*Schema)) {
+	for _, schema := range d.Components.Schemas {
+		schema.walk(visit)
+	}
+	for _, item := range d.Paths {
+		for _, op := range item {
+			for _, param := range op.Parameters {
+				param.Schema.walk(visit)
+			}
+			if op.RequestBody != nil {
+				for _, media := range op.RequestBody.Content {
+					media.Schema.walk(visit)
+				}
+			}
+			for _, resp := range op.Responses {
+				for _, media := range resp.Content {
+					media.Schema.walk(visit)
+				}
+				for _, header := range resp.Headers {
+					header.Schema.walk(visit)
+				}
+			}
+		}
+	}
+}
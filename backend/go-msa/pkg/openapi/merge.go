@@ -0,0 +1,96 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Source 병합할 문서와 그 중 포함할 경로 prefix
+type Source struct {
+	// Name 컴포넌트 이름 충돌 시 접두사로 사용 (예: "business")
+	Name string
+	// Prefix 이 경로로 시작하는 path만 포함. 빈 문자열이면 전체
+	Prefix   string
+	Document *Document
+}
+
+// Merge 여러 서비스 문서를 하나로 병합
+// 같은 이름의 컴포넌트는 내용이 같으면 공유하고, 다르면 소스 이름을 붙여 분리한 뒤 $ref를 고쳐 씀
+func Merge(info Info, sources []Source) (*Document, error) {
+	merged := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas:         make(map[string]*Schema),
+			SecuritySchemes: make(map[string]SecurityScheme),
+		},
+	}
+
+	for _, source := range sources {
+		doc, err := clone(source.Document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy %s document: %w", source.Name, err)
+		}
+
+		renames := make(map[string]string)
+		names := make([]string, 0, len(doc.Components.Schemas))
+		for name := range doc.Components.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			existing, taken := merged.Components.Schemas[name]
+			if taken && !reflect.DeepEqual(existing, doc.Components.Schemas[name]) {
+				renames[name] = source.Name + "_" + name
+			}
+		}
+		if len(renames) > 0 {
+			doc.walkSchemas(func(s *Schema) {
+				if target, ok := renames[strings.TrimPrefix(s.Ref, componentRefPrefix)]; ok && s.Ref != "" {
+					s.Ref = componentRefPrefix + target
+				}
+			})
+		}
+		for name, schema := range doc.Components.Schemas {
+			if target, ok := renames[name]; ok {
+				name = target
+			}
+			merged.Components.Schemas[name] = schema
+		}
+
+		for name, scheme := range doc.Components.SecuritySchemes {
+			merged.Components.SecuritySchemes[name] = scheme
+		}
+		if merged.Security == nil {
+			merged.Security = doc.Security
+		}
+		for path, item := range doc.Paths {
+			if source.Prefix != "" && path != source.Prefix && !strings.HasPrefix(path, source.Prefix+"/") {
+				continue
+			}
+			merged.Paths[path] = item
+		}
+	}
+
+	return merged, nil
+}
+
+// clone 이름 변경이 원본 문서에 영향을 주지 않도록 깊은 복사
+func clone(doc *Document) (*Document, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var copied Document
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	if copied.Components.Schemas == nil {
+		copied.Components.Schemas = make(map[string]*Schema)
+	}
+	return &copied, nil
+}
@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const componentRefPrefix = "#/components/schemas/"
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// schemaBuilder Go 타입을 스키마로 변환하며 이름 있는 구조체는 컴포넌트로 등록
+type schemaBuilder struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaBuilder(components map[string]*Schema) *schemaBuilder {
+	return &schemaBuilder{
+		components: components,
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf v의 타입에 대한 스키마 반환. 이름 있는 구조체는 $ref로 참조
+func (b *schemaBuilder) schemaOf(v interface{}) *Schema {
+	if v == nil {
+		return nil
+	}
+	return b.schemaFor(reflect.TypeOf(v))
+}
+
+func (b *schemaBuilder) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		return &Schema{Ref: componentRefPrefix + b.component(t)}
+	default:
+		// interface{} 등 형태를 알 수 없는 값은 제약 없는 스키마
+		return &Schema{}
+	}
+}
+
+// component 구조체를 컴포넌트로 등록하고 이름 반환. 다른 패키지의 같은 이름은 패키지명을 붙여 구분
+func (b *schemaBuilder) component(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := b.components[name]; taken {
+		pkg := t.PkgPath()
+		if i := strings.LastIndex(pkg, "/"); i >= 0 {
+			pkg = pkg[i+1:]
+		}
+		name = strings.ReplaceAll(pkg, "-", "_") + "_" + name
+	}
+
+	// 재귀 타입을 위해 스키마를 만들기 전에 이름부터 예약
+	b.names[t] = name
+	b.components[name] = &Schema{}
+	*b.components[name] = *b.structSchema(t)
+	return name
+}
+
+func (b *schemaBuilder) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.addFields(schema, t)
+	return schema
+}
+
+func (b *schemaBuilder) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// 임베디드 구조체 필드는 encoding/json처럼 바깥 객체로 펼침
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				b.addFields(schema, ft)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := b.schemaFor(field.Type)
+		rules := field.Tag.Get("validate")
+		if property.Ref == "" {
+			applyRules(property, rules)
+		}
+		if field.Type.Kind() == reflect.Pointer && property.Ref == "" {
+			property.Nullable = true
+		}
+		schema.Properties[name] = property
+
+		if hasRule(rules, "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// applyRules pkg/validation 태그 규칙을 스키마 제약으로 옮김
+func applyRules(schema *Schema, rules string) {
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "min", "max":
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			applyBound(schema, name == "min", n)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		case "uuid":
+			schema.Format = "uuid"
+		}
+	}
+}
+
+func applyBound(schema *Schema, lower bool, n float64) {
+	switch schema.Type {
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &n
+		} else {
+			schema.Maximum = &n
+		}
+	case "string":
+		length := int(n)
+		if lower {
+			schema.MinLength = &length
+		} else {
+			schema.MaxLength = &length
+		}
+	case "array":
+		length := int(n)
+		if lower {
+			schema.MinItems = &length
+		} else {
+			schema.MaxItems = &length
+		}
+	}
+}
+
+func hasRule(rules, rule string) bool {
+	for _, r := range strings.Split(rules, ",") {
+		if name, _, _ := strings.Cut(strings.TrimSpace(r), "="); name == rule {
+			return true
+		}
+	}
+	return false
+}
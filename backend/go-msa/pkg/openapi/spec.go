@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"pkg/models"
+)
+
+// Path 서비스가 문서를 제공하는 경로
+const Path = "/openapi.json"
+
+// Endpoint 라우트 하나의 문서. 요청/응답 타입은 제로 값으로 전달 (예: CreateProductRequest{})
+type Endpoint struct {
+	Summary     string
+	Description string
+	Tags        []string
+	// Query 쿼리 파라미터
+	Query []Parameter
+	// Request JSON 요청 본문 타입. nil이면 본문 없음
+	Request interface{}
+	// Response JSON 응답 본문 타입. nil이면 본문 없음
+	Response interface{}
+	// RequestMediaTypes JSON 외에 받는 본문 형식 (예: text/csv). 내용은 문자열로 문서화하고 검사하지 않음
+	RequestMediaTypes []string
+	// ResponseMediaTypes JSON 외에 성공 응답으로 보내는 본문 형식
+	ResponseMediaTypes []string
+	// Status 성공 상태 코드. 0이면 200
+	Status int
+	// Errors 문서화할 에러 상태 코드
+	Errors []int
+	// Public 인증 없이 호출 가능한 라우트
+	Public     bool
+	Deprecated bool
+}
+
+// Spec 라우트 등록과 나란히 채우는 서비스 OpenAPI 문서
+type Spec struct {
+	mu      sync.RWMutex
+	doc     Document
+	schemas *schemaBuilder
+}
+
+// New 빈 문서 생성. 보안 스킴과 공통 에러 스키마가 미리 등록됨
+func New(title, version string) *Spec {
+	doc := Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				BearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		Security: []SecurityRequirement{{BearerAuth: []string{}}},
+	}
+
+	s := &Spec{doc: doc, schemas: newSchemaBuilder(doc.Components.Schemas)}
+	s.schemas.schemaOf(models.APIResponse{})
+	doc.Components.Schemas["ProblemDetails"] = problemSchema()
+	return s
+}
+
+// Add ServeMux와 같은 "METHOD /path" 패턴으로 라우트 문서 추가
+func (s *Spec) Add(pattern string, e Endpoint) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok || !strings.HasPrefix(strings.TrimSpace(path), "/") {
+		panic(fmt.Sprintf("openapi: pattern %q must be \"METHOD /path\"", pattern))
+	}
+	path = strings.TrimSpace(path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op := &Operation{
+		OperationID: operationID(method, path),
+		Summary:     e.Summary,
+		Description: e.Description,
+		Tags:        e.Tags,
+		Parameters:  append(pathParameters(path), e.Query...),
+		Responses:   make(map[string]*Response),
+		Deprecated:  e.Deprecated,
+	}
+	if e.Public {
+		op.Security = []SecurityRequirement{{}}
+	}
+
+	hasRequest := e.Request != nil || len(e.RequestMediaTypes) > 0
+	if hasRequest {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  s.content(e.Request, e.RequestMediaTypes),
+		}
+	}
+
+	status := e.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	if e.Response != nil || len(e.ResponseMediaTypes) > 0 {
+		success.Content = s.content(e.Response, e.ResponseMediaTypes)
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	errors := e.Errors
+	if hasRequest {
+		errors = append(errors, http.StatusBadRequest)
+	}
+	if !e.Public {
+		errors = append(errors, http.StatusUnauthorized)
+	}
+	for _, code := range errors {
+		op.Responses[strconv.Itoa(code)] = errorResponse(code)
+	}
+
+	item, ok := s.doc.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		s.doc.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = op
+}
+
+// content JSON 타입과 추가 미디어 타입으로 본문 정의 작성. 추가 형식은 문자열 스키마
+func (s *Spec) content(v interface{}, mediaTypes []string) map[string]MediaType {
+	content := make(map[string]MediaType, len(mediaTypes)+1)
+	if v != nil {
+		content["application/json"] = MediaType{Schema: s.schemas.schemaOf(v)}
+	}
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = MediaType{Schema: &Schema{Type: "string"}}
+	}
+	return content
+}
+
+// Document 현재 문서의 복사본 반환. Add가 수정하는 맵(Paths, PathItem, Schemas)을 복사하므로
+// 반환 후 라우트가 추가되어도 직렬화와 경합하지 않음. 오퍼레이션과 스키마는 Add 이후 바뀌지 않아 공유
+func (s *Spec) Document() *Document {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	doc := s.doc
+	doc.Paths = make(map[string]PathItem, len(s.doc.Paths))
+	for path, item := range s.doc.Paths {
+		doc.Paths[path] = maps.Clone(item)
+	}
+	doc.Components.Schemas = maps.Clone(s.doc.Components.Schemas)
+	return &doc
+}
+
+// Undocumented 문서가 없는 ServeMux 패턴 반환 (router.Versions.Patterns 결과를 전달)
+// 메서드 없는 패턴은 해당 경로에 오퍼레이션이 하나라도 있으면 문서화된 것으로 봄
+func (s *Spec) Undocumented(patterns []string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var missing []string
+	for _, pattern := range patterns {
+		method, path, hasMethod := strings.Cut(pattern, " ")
+		if !hasMethod {
+			path = method
+		}
+
+		item, ok := s.doc.Paths[strings.TrimSpace(path)]
+		if !ok || len(item) == 0 {
+			missing = append(missing, pattern)
+			continue
+		}
+		if hasMethod {
+			if _, ok := item[strings.ToLower(method)]; !ok {
+				missing = append(missing, pattern)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// ServeHTTP 문서를 JSON으로 응답
+func (s *Spec) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.Document())
+}
+
+// operationID "GET /v1/business/products/{id}" -> "getV1BusinessProductsById"
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "{") {
+			segment = "by_" + strings.Trim(segment, "{}.")
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '_' || r == '-' }) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// pathParameters {name} 와일드카드를 필수 문자열 path 파라미터로 변환
+func pathParameters(path string) []Parameter {
+	var params []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.Trim(segment, "{}"), "...")
+		params = append(params, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	return params
+}
+
+// errorResponse ERROR_FORMAT 설정에 따라 나가는 두 에러 형식을 모두 문서화
+func errorResponse(status int) *Response {
+	return &Response{
+		Description: http.StatusText(status),
+		Content: map[string]MediaType{
+			"application/json":        {Schema: &Schema{Ref: componentRefPrefix + "APIResponse"}},
+			models.ProblemContentType: {Schema: &Schema{Ref: componentRefPrefix + "ProblemDetails"}},
+		},
+	}
+}
+
+// problemSchema ProblemDetails는 MarshalJSON으로 직렬화되므로 직접 정의
+func problemSchema() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"type":       {Type: "string"},
+			"title":      {Type: "string"},
+			"status":     {Type: "integer", Format: "int32"},
+			"detail":     {Type: "string"},
+			"instance":   {Type: "string"},
+			"code":       {Type: "string"},
+			"request_id": {Type: "string"},
+			"errors":     {Type: "array", Items: &Schema{Ref: componentRefPrefix + "FieldError"}},
+		},
+		Required: []string{"type", "title", "status"},
+	}
+}
+
+// QueryParam 쿼리 파라미터 정의 헬퍼
+func QueryParam(name, typ, description string, required bool) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Required:    required,
+		Schema:      &Schema{Type: typ},
+	}
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type testProductRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type testProductResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestSpecAdd(t *testing.T) {
+	spec := New("business", "v1")
+	spec.Add("POST /v1/business/products", Endpoint{
+		Summary:  "Create a product",
+		Request:  testProductRequest{},
+		Response: testProductResponse{},
+		Status:   http.StatusCreated,
+		Errors:   []int{http.StatusConflict},
+	})
+	spec.Add("GET /v1/business/products/{id}", Endpoint{Response: testProductResponse{}, Public: true})
+
+	doc := spec.Document()
+	create := doc.Paths["/v1/business/products"]["post"]
+	if create == nil {
+		t.Fatal("POST operation missing")
+	}
+	if create.OperationID != "postV1BusinessProducts" {
+		t.Errorf("OperationID = %q", create.OperationID)
+	}
+	for _, status := range []string{"201", "400", "401", "409"} {
+		if create.Responses[status] == nil {
+			t.Errorf("response %s missing", status)
+		}
+	}
+	if create.RequestBody == nil || create.RequestBody.Content["application/json"].Schema.Ref != componentRefPrefix+"testProductRequest" {
+		t.Errorf("RequestBody = %+v", create.RequestBody)
+	}
+
+	get := doc.Paths["/v1/business/products/{id}"]["get"]
+	if get == nil || len(get.Parameters) != 1 || get.Parameters[0].Name != "id" || get.Parameters[0].In != "path" {
+		t.Fatalf("GET operation = %+v", get)
+	}
+	if get.Responses["401"] != nil {
+		t.Error("public route documents 401")
+	}
+}
+
+func TestSpecUndocumented(t *testing.T) {
+	spec := New("business", "v1")
+	spec.Add("GET /v1/business/products", Endpoint{})
+	spec.Add("POST /v1/business/products", Endpoint{})
+
+	missing := spec.Undocumented([]string{
+		"/v1/business/products",
+		"GET /v1/business/products",
+		"DELETE /v1/business/products",
+		"GET /v1/business/orders",
+	})
+
+	want := []string{"DELETE /v1/business/products", "GET /v1/business/orders"}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("Undocumented() = %v, want %v", missing, want)
+	}
+}
+
+func TestSpecDocumentIsASnapshot(t *testing.T) {
+	spec := New("business", "v1")
+	spec.Add("GET /v1/business/products", Endpoint{})
+	doc := spec.Document()
+
+	spec.Add("POST /v1/business/products", Endpoint{Request: testProductRequest{}})
+	spec.Add("GET /v1/business/orders", Endpoint{})
+
+	if len(doc.Paths) != 1 || len(doc.Paths["/v1/business/products"]) != 1 {
+		t.Errorf("earlier Document() changed after Add: %v", doc.Paths)
+	}
+	if _, ok := doc.Components.Schemas["testProductRequest"]; ok {
+		t.Error("earlier Document() gained a schema after Add")
+	}
+}
+
+func TestSpecDocumentConcurrentWithAdd(t *testing.T) {
+	spec := New("business", "v1")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			spec.Add(fmt.Sprintf("GET /v1/business/items%d/{id}", i), Endpoint{Response: testProductResponse{}})
+			spec.Add(fmt.Sprintf("POST /v1/business/items%d/{id}", i), Endpoint{Request: testProductRequest{}})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if _, err := json.Marshal(spec.Document()); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,424 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"pkg/models"
+)
+
+// Validator 문서의 오퍼레이션 정의로 요청/응답을 검사
+// 필드 에러 메시지는 pkg/validation과 같은 문구를 사용
+type Validator struct {
+	doc    *Document
+	routes []route
+}
+
+type route struct {
+	segments []string
+	item     PathItem
+}
+
+// Match 매칭된 오퍼레이션과 path 파라미터 값
+type Match struct {
+	Path       string
+	Operation  *Operation
+	PathParams map[string]string
+}
+
+// NewValidator 문서의 경로 템플릿을 미리 분해해 둔 Validator 생성
+func NewValidator(doc *Document) *Validator {
+	v := &Validator{doc: doc}
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	// 와일드카드보다 고정 세그먼트가 먼저 매칭되도록 정렬 (ServeMux와 같은 우선순위)
+	sort.Slice(paths, func(i, j int) bool {
+		return strings.Count(paths[i], "{") < strings.Count(paths[j], "{")
+	})
+	for _, path := range paths {
+		v.routes = append(v.routes, route{segments: splitPath(path), item: doc.Paths[path]})
+	}
+	return v
+}
+
+// Find 메서드와 요청 경로에 해당하는 오퍼레이션 검색
+func (v *Validator) Find(method, path string) (Match, bool) {
+	segments := splitPath(path)
+	for _, rt := range v.routes {
+		params, ok := matchSegments(rt.segments, segments)
+		if !ok {
+			continue
+		}
+		op, ok := rt.item[strings.ToLower(method)]
+		if !ok {
+			continue
+		}
+		return Match{Path: "/" + strings.Join(rt.segments, "/"), Operation: op, PathParams: params}, true
+	}
+	return Match{}, false
+}
+
+// ValidateRequest path/query 파라미터와 JSON 본문 검사. body가 nil이면 본문 검사 생략
+func (v *Validator) ValidateRequest(m Match, query map[string][]string, contentType string, body []byte) []models.FieldError {
+	c := &checker{doc: v.doc}
+
+	for _, param := range m.Operation.Parameters {
+		switch param.In {
+		case "path":
+			c.checkParam(param, m.PathParams[param.Name])
+		case "query":
+			values, present := query[param.Name]
+			if !present || len(values) == 0 {
+				if param.Required {
+					c.add(param.Name, "is required")
+				}
+				continue
+			}
+			c.checkParam(param, values[0])
+		}
+	}
+
+	if rb := m.Operation.RequestBody; rb != nil && body != nil {
+		c.checkBody("body", rb.Content, rb.Required, contentType, body)
+	}
+	return c.errors
+}
+
+// ReadsBody 이 Content-Type의 요청 본문을 ValidateRequest가 읽어서 검사하는지 확인.
+// 문서에 JSON 외 형식으로 정의된 본문(CSV, NDJSON 업로드 등)은 검사하지 않으므로 읽지 않아도 됨
+func (m Match) ReadsBody(contentType string) bool {
+	rb := m.Operation.RequestBody
+	if rb == nil {
+		return false
+	}
+	mediaType, media, ok := mediaFor(rb.Content, contentType)
+	if !ok {
+		// 정의되지 않은 Content-Type은 본문이 있을 때만 보고
+		return true
+	}
+	return media.Schema != nil && (mediaType == "" || isJSON(mediaType))
+}
+
+// ValidateResponse 문서에 정의된 응답 본문과 비교. 정의되지 않은 상태 코드는 에러로 보고
+func (v *Validator) ValidateResponse(m Match, status int, contentType string, body []byte) []models.FieldError {
+	c := &checker{doc: v.doc}
+
+	resp, ok := m.Operation.Responses[strconv.Itoa(status)]
+	if !ok {
+		resp, ok = m.Operation.Responses["default"]
+	}
+	if !ok {
+		c.add("status", fmt.Sprintf("%d is not documented", status))
+		return c.errors
+	}
+	if len(resp.Content) > 0 && len(body) > 0 {
+		c.checkBody("response", resp.Content, false, contentType, body)
+	}
+	return c.errors
+}
+
+type checker struct {
+	doc    *Document
+	errors []models.FieldError
+}
+
+func (c *checker) add(field, message string) {
+	c.errors = append(c.errors, models.FieldError{Field: field, Message: message})
+}
+
+func (c *checker) checkParam(param Parameter, raw string) {
+	schema := c.resolve(param.Schema)
+	if schema == nil {
+		return
+	}
+
+	var value interface{} = raw
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.add(param.Name, "must be an integer")
+			return
+		}
+		value = json.Number(strconv.FormatInt(n, 10))
+	case "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			c.add(param.Name, "must be a number")
+			return
+		}
+		value = json.Number(raw)
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.add(param.Name, "must be a boolean")
+			return
+		}
+		value = b
+	}
+	c.check(param.Name, schema, value)
+}
+
+func (c *checker) checkBody(field string, content map[string]MediaType, required bool, contentType string, body []byte) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if required {
+			c.add(field, "is required")
+		}
+		return
+	}
+
+	mediaType, media, ok := mediaFor(content, contentType)
+	if !ok {
+		c.add(field, "has unsupported content type "+strconv.Quote(mediaType))
+		return
+	}
+	if media.Schema == nil || !isJSON(mediaType) && mediaType != "" {
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		c.add(field, "must be valid JSON")
+		return
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		c.add(field, "must contain a single JSON value")
+		return
+	}
+	c.check("", media.Schema, value)
+}
+
+// mediaFor Content-Type 헤더에 해당하는 미디어 타입 정의 검색
+func mediaFor(content map[string]MediaType, contentType string) (string, MediaType, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = ""
+	}
+	media, ok := content[mediaType]
+	if !ok {
+		// 문서가 JSON만 정의하면 Content-Type 누락도 JSON으로 간주 (서비스의 디코더와 동일)
+		if fallback, hasJSON := content["application/json"]; hasJSON && mediaType == "" {
+			media, ok = fallback, true
+		}
+	}
+	return mediaType, media, ok
+}
+
+// resolve $ref를 따라 실제 스키마 반환
+func (c *checker) resolve(schema *Schema) *Schema {
+	for depth := 0; schema != nil && schema.Ref != ""; depth++ {
+		if depth > 32 || !strings.HasPrefix(schema.Ref, componentRefPrefix) {
+			return nil
+		}
+		schema = c.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, componentRefPrefix)]
+	}
+	return schema
+}
+
+// check value가 스키마를 만족하는지 검사. 필드당 첫 번째 위반만 기록
+func (c *checker) check(field string, schema *Schema, value interface{}) {
+	schema = c.resolve(schema)
+	if schema == nil {
+		return
+	}
+	name := field
+	if name == "" {
+		name = "body"
+	}
+
+	if value == nil {
+		if !schema.Nullable && schema.Type != "" {
+			c.add(name, "must not be null")
+		}
+		return
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		options := make([]string, len(schema.Enum))
+		for i, option := range schema.Enum {
+			options[i] = fmt.Sprint(option)
+		}
+		c.add(name, "must be one of: "+strings.Join(options, ", "))
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			c.add(name, "must be a string")
+			return
+		}
+		c.checkString(name, schema, s)
+	case "integer", "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			c.add(name, typeMessage(schema.Type))
+			return
+		}
+		c.checkNumber(name, schema, n)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			c.add(name, "must be a boolean")
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			c.add(name, "must be an array")
+			return
+		}
+		if !c.checkLength(name, schema.MinItems, schema.MaxItems, len(items), "items") {
+			return
+		}
+		for i, item := range items {
+			c.check(fmt.Sprintf("%s[%d]", field, i), schema.Items, item)
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			c.add(name, "must be an object")
+			return
+		}
+		c.checkObject(field, schema, object)
+	}
+}
+
+func (c *checker) checkString(name string, schema *Schema, s string) {
+	if !c.checkLength(name, schema.MinLength, schema.MaxLength, utf8.RuneCountInString(s), "characters") {
+		return
+	}
+	switch schema.Format {
+	case "uuid":
+		if _, err := uuid.Parse(s); err != nil {
+			c.add(name, "must be a valid UUID")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			c.add(name, "must be an RFC 3339 timestamp")
+		}
+	}
+}
+
+func (c *checker) checkNumber(name string, schema *Schema, n json.Number) {
+	f, err := n.Float64()
+	if err != nil {
+		c.add(name, typeMessage(schema.Type))
+		return
+	}
+	if schema.Type == "integer" {
+		if _, err := n.Int64(); err != nil {
+			c.add(name, typeMessage(schema.Type))
+			return
+		}
+	}
+	if schema.Minimum != nil && f < *schema.Minimum {
+		c.add(name, "must be greater than or equal to "+strconv.FormatFloat(*schema.Minimum, 'f', -1, 64))
+		return
+	}
+	if schema.Maximum != nil && f > *schema.Maximum {
+		c.add(name, "must be less than or equal to "+strconv.FormatFloat(*schema.Maximum, 'f', -1, 64))
+	}
+}
+
+func (c *checker) checkLength(name string, min, max *int, n int, unit string) bool {
+	if min != nil && n < *min {
+		c.add(name, fmt.Sprintf("must contain at least %d %s", *min, unit))
+		return false
+	}
+	if max != nil && n > *max {
+		c.add(name, fmt.Sprintf("must contain at most %d %s", *max, unit))
+		return false
+	}
+	return true
+}
+
+func (c *checker) checkObject(field string, schema *Schema, object map[string]interface{}) {
+	for _, required := range schema.Required {
+		if _, ok := object[required]; !ok {
+			c.add(join(field, required), "is required")
+		}
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if property, ok := schema.Properties[key]; ok {
+			c.check(join(field, key), property, object[key])
+		} else if schema.AdditionalProperties != nil {
+			c.check(join(field, key), schema.AdditionalProperties, object[key])
+		}
+	}
+}
+
+func typeMessage(typ string) string {
+	if typ == "integer" {
+		return "must be an integer"
+	}
+	return "must be a " + typ
+}
+
+func join(field, key string) string {
+	if field == "" {
+		return key
+	}
+	return field + "." + key
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	actual := fmt.Sprint(value)
+	for _, option := range enum {
+		if fmt.Sprint(option) == actual {
+			return true
+		}
+	}
+	return false
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// matchSegments {name}은 한 세그먼트, {name...}은 나머지 전체와 매칭
+func matchSegments(template, segments []string) (map[string]string, bool) {
+	params := make(map[string]string)
+	for i, part := range template {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "...}") {
+			params[strings.TrimSuffix(strings.Trim(part, "{}"), "...")] = strings.Join(segments[min(i, len(segments)):], "/")
+			return params, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[strings.Trim(part, "{}")] = segments[i]
+			continue
+		}
+		if part != segments[i] {
+			return nil, false
+		}
+	}
+	return params, len(template) == len(segments)
+}
+
+// isJSON 단일 JSON 값으로 검사할 미디어 타입인지 확인. application/x-ndjson 같은 줄 단위 형식은 제외
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,175 @@
+package openapi
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"pkg/models"
+)
+
+type testStockRequest struct {
+	Delta  int32    `json:"delta" validate:"required,min=-100,max=100"`
+	Reason string   `json:"reason,omitempty" validate:"oneof=restock damage"`
+	Tags   []string `json:"tags,omitempty" validate:"max=2"`
+	Note   *string  `json:"note,omitempty" validate:"max=5"`
+}
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+	spec := New("business", "v1")
+	spec.Add("GET /v1/business/products", Endpoint{
+		Query: []Parameter{
+			QueryParam("limit", "integer", "", false),
+			QueryParam("active", "boolean", "", false),
+			QueryParam("q", "string", "", true),
+		},
+	})
+	spec.Add("GET /v1/business/products/export", Endpoint{})
+	spec.Add("POST /v1/business/products/{id}/stock", Endpoint{Request: testStockRequest{}})
+	spec.Add("POST /v1/business/products/imports", Endpoint{RequestMediaTypes: []string{"text/csv", "application/x-ndjson"}})
+	spec.Add("GET /v1/business/files/{path...}", Endpoint{})
+
+	doc := spec.Document()
+	doc.Paths["/v1/business/products/{id}/stock"]["post"].Parameters[0].Schema = &Schema{Type: "string", Format: "uuid"}
+	return NewValidator(doc)
+}
+
+func TestValidatorFind(t *testing.T) {
+	v := newTestValidator(t)
+
+	tests := []struct {
+		method, path string
+		want         string
+		params       map[string]string
+	}{
+		// Fixed segments win over wildcards, as in ServeMux
+		{method: http.MethodGet, path: "/v1/business/products/export", want: "/v1/business/products/export", params: map[string]string{}},
+		{method: http.MethodPost, path: "/v1/business/products/abc/stock", want: "/v1/business/products/{id}/stock", params: map[string]string{"id": "abc"}},
+		{method: http.MethodGet, path: "/v1/business/files/a/b.csv", want: "/v1/business/files/{path...}", params: map[string]string{"path": "a/b.csv"}},
+		{method: http.MethodDelete, path: "/v1/business/products"},
+		{method: http.MethodPost, path: "/v1/business/products//stock"},
+		{method: http.MethodGet, path: "/v1/business/orders"},
+	}
+	for _, tt := range tests {
+		match, ok := v.Find(tt.method, tt.path)
+		if ok != (tt.want != "") || match.Path != tt.want || ok && !reflect.DeepEqual(match.PathParams, tt.params) {
+			t.Errorf("Find(%s %s) = %q %v, %v, want %q %v", tt.method, tt.path, match.Path, match.PathParams, ok, tt.want, tt.params)
+		}
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	v := newTestValidator(t)
+	const stockPath = "/v1/business/products/0b6f4c1e-8f1a-4a51-9d2b-6f7f0e5d1c2a/stock"
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		query       string
+		contentType string
+		body        []byte
+		want        []models.FieldError
+	}{
+		{name: "valid query", method: http.MethodGet, path: "/v1/business/products", query: "q=bolt&limit=10&active=true"},
+		{name: "missing required query", method: http.MethodGet, path: "/v1/business/products", want: []models.FieldError{{Field: "q", Message: "is required"}}},
+		{
+			name: "malformed query", method: http.MethodGet, path: "/v1/business/products", query: "q=bolt&limit=ten&active=maybe",
+			want: []models.FieldError{{Field: "limit", Message: "must be an integer"}, {Field: "active", Message: "must be a boolean"}},
+		},
+		{
+			name: "path parameter format", method: http.MethodPost, path: "/v1/business/products/abc/stock", body: []byte(`{"delta":1}`),
+			want: []models.FieldError{{Field: "id", Message: "must be a valid UUID"}},
+		},
+		{name: "valid body", method: http.MethodPost, path: stockPath, contentType: "application/json; charset=utf-8", body: []byte(`{"delta":-3,"reason":"damage","tags":["a"],"note":null}`)},
+		{name: "missing content type is JSON", method: http.MethodPost, path: stockPath, body: []byte(`{"delta":1}`)},
+		{name: "body left unchecked", method: http.MethodPost, path: stockPath},
+		{name: "empty body", method: http.MethodPost, path: stockPath, body: []byte(" "), want: []models.FieldError{{Field: "body", Message: "is required"}}},
+		{
+			name: "body constraints", method: http.MethodPost, path: stockPath, contentType: "application/json",
+			body: []byte(`{"delta":101,"reason":"theft","tags":["a","b","c"],"note":"too long"}`),
+			want: []models.FieldError{
+				{Field: "delta", Message: "must be less than or equal to 100"},
+				{Field: "note", Message: "must contain at most 5 characters"},
+				{Field: "reason", Message: "must be one of: restock, damage"},
+				{Field: "tags", Message: "must contain at most 2 items"},
+			},
+		},
+		{
+			name: "body types", method: http.MethodPost, path: stockPath, contentType: "application/json",
+			body: []byte(`{"delta":1.5,"tags":"a"}`),
+			want: []models.FieldError{{Field: "delta", Message: "must be an integer"}, {Field: "tags", Message: "must be an array"}},
+		},
+		{name: "missing required field", method: http.MethodPost, path: stockPath, body: []byte(`{}`), want: []models.FieldError{{Field: "delta", Message: "is required"}}},
+		{name: "invalid JSON", method: http.MethodPost, path: stockPath, body: []byte(`{"delta":`), want: []models.FieldError{{Field: "body", Message: "must be valid JSON"}}},
+		{name: "trailing JSON", method: http.MethodPost, path: stockPath, body: []byte(`{"delta":1} {}`), want: []models.FieldError{{Field: "body", Message: "must contain a single JSON value"}}},
+		{
+			name: "undocumented content type", method: http.MethodPost, path: stockPath, contentType: "text/plain", body: []byte("delta=1"),
+			want: []models.FieldError{{Field: "body", Message: `has unsupported content type "text/plain"`}},
+		},
+		{name: "non-JSON media types are not parsed", method: http.MethodPost, path: "/v1/business/products/imports", contentType: "text/csv", body: []byte("not,json\n")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := v.Find(tt.method, tt.path)
+			if !ok {
+				t.Fatalf("Find(%s %s) found nothing", tt.method, tt.path)
+			}
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := v.ValidateRequest(match, query, tt.contentType, tt.body)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchReadsBody(t *testing.T) {
+	v := newTestValidator(t)
+	stock, _ := v.Find(http.MethodPost, "/v1/business/products/abc/stock")
+	imports, _ := v.Find(http.MethodPost, "/v1/business/products/imports")
+	export, _ := v.Find(http.MethodGet, "/v1/business/products/export")
+
+	tests := []struct {
+		name        string
+		match       Match
+		contentType string
+		want        bool
+	}{
+		{name: "JSON", match: stock, contentType: "application/json", want: true},
+		{name: "JSON by default", match: stock, want: true},
+		{name: "undocumented type is reported", match: stock, contentType: "text/plain", want: true},
+		{name: "CSV upload", match: imports, contentType: "text/csv; charset=utf-8"},
+		{name: "NDJSON upload", match: imports, contentType: "application/x-ndjson"},
+		{name: "no body", match: export, contentType: "application/json"},
+	}
+	for _, tt := range tests {
+		if got := tt.match.ReadsBody(tt.contentType); got != tt.want {
+			t.Errorf("%s: ReadsBody(%q) = %v, want %v", tt.name, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	spec := New("business", "v1")
+	spec.Add("GET /v1/business/products/{id}", Endpoint{Response: testProductResponse{}, Errors: []int{http.StatusNotFound}})
+	v := NewValidator(spec.Document())
+	match, _ := v.Find(http.MethodGet, "/v1/business/products/1")
+
+	if got := v.ValidateResponse(match, http.StatusOK, "application/json", []byte(`{"id":"1","name":"Bolt"}`)); len(got) != 0 {
+		t.Errorf("valid response: %v", got)
+	}
+	if got := v.ValidateResponse(match, http.StatusOK, "application/json", []byte(`{"id":1}`)); len(got) != 1 || got[0].Field != "id" {
+		t.Errorf("wrong type: %v", got)
+	}
+	if got := v.ValidateResponse(match, http.StatusTeapot, "", nil); len(got) != 1 || got[0].Message != "418 is not documented" {
+		t.Errorf("undocumented status: %v", got)
+	}
+}
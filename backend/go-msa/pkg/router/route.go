@@ -0,0 +1,44 @@
+package router
+
+import (
+	"context"
+	"net/http"
+)
+
+type routeKey struct{}
+
+type routeHolder struct {
+	pattern string
+}
+
+// WithRoute 매칭된 라우트 패턴을 바깥 미들웨어에서도 읽을 수 있도록 요청 컨텍스트에 홀더 추가 (이미 있으면 그대로 반환)
+//
+// 미들웨어가 r.WithContext로 요청을 복사하면 ServeMux가 기록한 r.Pattern은 복사본에만 남기 때문에 필요
+func WithRoute(r *http.Request) *http.Request {
+	if _, ok := r.Context().Value(routeKey{}).(*routeHolder); ok {
+		return r
+	}
+	return r.WithContext(context.WithValue(r.Context(), routeKey{}, &routeHolder{}))
+}
+
+// RecordRoute ServeMux를 직접 감싸 매칭된 패턴을 홀더에 기록하는 미들웨어
+func RecordRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		if holder, ok := r.Context().Value(routeKey{}).(*routeHolder); ok && r.Pattern != "" {
+			holder.pattern = r.Pattern
+		}
+	})
+}
+
+// Route 매칭된 ServeMux 패턴 반환. 라우팅 전이거나 매칭되지 않았으면 빈 문자열
+func Route(r *http.Request) string {
+	if r.Pattern != "" {
+		return r.Pattern
+	}
+	if holder, ok := r.Context().Value(routeKey{}).(*routeHolder); ok {
+		return holder.pattern
+	}
+	return ""
+}
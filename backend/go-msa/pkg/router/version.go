@@ -0,0 +1,178 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// VersionPolicy API 버전의 폐기 일정. 값이 없는 필드는 헤더를 보내지 않음
+type VersionPolicy struct {
+	// DeprecatedAt 폐기 공지 시점 (Deprecation 헤더, RFC 9745)
+	DeprecatedAt time.Time
+	// SunsetAt 제거 예정 시점 (Sunset 헤더, RFC 8594)
+	SunsetAt time.Time
+	// Link 마이그레이션 안내 문서 URL (Link 헤더, rel="deprecation")
+	Link string
+}
+
+// Deprecated 폐기 공지 또는 제거 일정이 잡힌 버전인지 여부
+func (p VersionPolicy) Deprecated() bool {
+	return !p.DeprecatedAt.IsZero() || !p.SunsetAt.IsZero()
+}
+
+// Headers 폐기된 버전의 응답에 Deprecation/Sunset/Link 헤더를 추가하는 미들웨어
+func (p VersionPolicy) Headers(next http.Handler) http.Handler {
+	headers := p.headers()
+	if headers == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers.set(w.Header())
+		next.ServeHTTP(w, r)
+	})
+}
+
+// headers 정책을 응답 헤더 값으로 변환. 폐기되지 않은 버전이면 nil
+func (p VersionPolicy) headers() *deprecationHeaders {
+	if !p.Deprecated() {
+		return nil
+	}
+
+	headers := &deprecationHeaders{deprecation: "true"}
+	if !p.DeprecatedAt.IsZero() {
+		headers.deprecation = fmt.Sprintf("@%d", p.DeprecatedAt.Unix())
+	}
+	if !p.SunsetAt.IsZero() {
+		headers.sunset = p.SunsetAt.UTC().Format(http.TimeFormat)
+	}
+	if p.Link != "" {
+		headers.link = fmt.Sprintf(`<%s>; rel="deprecation"; type="text/html"`, p.Link)
+	}
+	return headers
+}
+
+// deprecationHeaders 요청마다 다시 계산하지 않도록 미리 만들어 둔 폐기 헤더 값
+type deprecationHeaders struct {
+	deprecation string
+	sunset      string
+	link        string
+}
+
+func (d *deprecationHeaders) set(h http.Header) {
+	h.Set("Deprecation", d.deprecation)
+	if d.sunset != "" {
+		h.Set("Sunset", d.sunset)
+	}
+	if d.link != "" {
+		h.Add("Link", d.link)
+	}
+}
+
+// Versions 한 서비스의 여러 API 버전을 같은 ServeMux에 나란히 등록
+type Versions struct {
+	mux     *http.ServeMux
+	service string
+	mounted map[string]*VersionedRoutes
+}
+
+// NewVersions 새로운 Versions 인스턴스 생성
+func NewVersions(mux *http.ServeMux, service string) *Versions {
+	return &Versions{
+		mux:     mux,
+		service: service,
+		mounted: make(map[string]*VersionedRoutes),
+	}
+}
+
+// Mount 버전 등록 후 해당 버전의 라우트 등록기 반환.
+// 같은 버전을 다시 마운트하면 정책만 갱신하며, 이미 등록된 라우트에도 다음 요청부터 적용됨
+func (v *Versions) Mount(version string, policy VersionPolicy) *VersionedRoutes {
+	if routes, ok := v.mounted[version]; ok {
+		routes.setPolicy(policy)
+		return routes
+	}
+
+	routes := &VersionedRoutes{
+		PathBuilder: NewPathBuilder(version, v.service),
+		mux:         v.mux,
+	}
+	routes.setPolicy(policy)
+	v.mounted[version] = routes
+	return routes
+}
+
+// Patterns 모든 버전에 등록된 ServeMux 패턴 목록 (문서 누락 검사용)
+func (v *Versions) Patterns() []string {
+	var patterns []string
+	for _, version := range v.List() {
+		patterns = append(patterns, v.mounted[version].patterns...)
+	}
+	return patterns
+}
+
+// List 등록된 버전 목록 (정렬됨)
+func (v *Versions) List() []string {
+	versions := make([]string, 0, len(v.mounted))
+	for version := range v.mounted {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// VersionedRoutes 특정 버전의 경로 빌더 겸 라우트 등록기
+type VersionedRoutes struct {
+	*PathBuilder
+	mux *http.ServeMux
+	// policy 요청 처리 중에도 Mount로 교체될 수 있으므로 atomic으로 보관
+	policy   atomic.Pointer[versionPolicyState]
+	patterns []string
+}
+
+// versionPolicyState 정책과 그로부터 계산한 응답 헤더
+type versionPolicyState struct {
+	policy  VersionPolicy
+	headers *deprecationHeaders
+}
+
+// Policy 버전의 폐기 일정 반환
+func (vr *VersionedRoutes) Policy() VersionPolicy {
+	return vr.policy.Load().policy
+}
+
+func (vr *VersionedRoutes) setPolicy(policy VersionPolicy) {
+	vr.policy.Store(&versionPolicyState{policy: policy, headers: policy.headers()})
+}
+
+// Pattern 버전 기준 상대 패턴을 ServeMux 패턴으로 변환
+// 예: Pattern("GET products/{id}") -> "GET /{version}/{service}/products/{id}"
+func (vr *VersionedRoutes) Pattern(pattern string) string {
+	method, path, found := strings.Cut(pattern, " ")
+	if !found {
+		return vr.Path(strings.Split(pattern, "/")...)
+	}
+	return method + " " + vr.Path(strings.Split(strings.TrimSpace(path), "/")...)
+}
+
+// Handle 버전 기준 상대 패턴으로 핸들러 등록. 폐기된 버전이면 폐기 헤더가 함께 전송됨.
+// 정책은 요청 시점에 읽으므로 등록 후 Mount로 바뀐 정책도 반영됨
+func (vr *VersionedRoutes) Handle(pattern string, handler http.Handler) {
+	full := vr.Pattern(pattern)
+	vr.mux.Handle(full, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if headers := vr.policy.Load().headers; headers != nil {
+			headers.set(w.Header())
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	vr.patterns = append(vr.patterns, full)
+}
+
+// HandleFunc 버전 기준 상대 패턴으로 핸들러 함수 등록
+func (vr *VersionedRoutes) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	vr.Handle(pattern, http.HandlerFunc(handler))
+}
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func serve(mux *http.ServeMux, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestVersionsMountSideBySide(t *testing.T) {
+	mux := http.NewServeMux()
+	versions := NewVersions(mux, "business")
+	v1 := versions.Mount("v1", VersionPolicy{})
+	v2 := versions.Mount("v2", VersionPolicy{})
+	v1.HandleFunc("GET products/{id}", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("v1 " + r.PathValue("id"))) })
+	v2.HandleFunc("GET products/{id}", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("v2 " + r.PathValue("id"))) })
+
+	if got := serve(mux, "/v1/business/products/7").Body.String(); got != "v1 7" {
+		t.Errorf("v1 body = %q", got)
+	}
+	if got := serve(mux, "/v2/business/products/7").Body.String(); got != "v2 7" {
+		t.Errorf("v2 body = %q", got)
+	}
+
+	want := []string{"GET /v1/business/products/{id}", "GET /v2/business/products/{id}"}
+	if got := versions.Patterns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Patterns() = %v, want %v", got, want)
+	}
+}
+
+func TestVersionedRoutesDeprecationHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	versions := NewVersions(mux, "business")
+	versions.Mount("v1", VersionPolicy{}).HandleFunc("GET products", func(http.ResponseWriter, *http.Request) {})
+
+	if w := serve(mux, "/v1/business/products"); w.Header().Get("Deprecation") != "" {
+		t.Fatalf("Deprecation = %q on a current version", w.Header().Get("Deprecation"))
+	}
+
+	// 라우트 등록 후 다시 마운트한 정책도 기존 라우트에 적용되어야 함
+	deprecatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	versions.Mount("v1", VersionPolicy{DeprecatedAt: deprecatedAt, SunsetAt: sunsetAt, Link: "https://docs.example.com/v2"})
+
+	w := serve(mux, "/v1/business/products")
+	if got, want := w.Header().Get("Deprecation"), "@1767225600"; got != want {
+		t.Errorf("Deprecation = %q, want %q", got, want)
+	}
+	if got, want := w.Header().Get("Sunset"), "Wed, 01 Jul 2026 00:00:00 GMT"; got != want {
+		t.Errorf("Sunset = %q, want %q", got, want)
+	}
+	if got, want := w.Header().Get("Link"), `<https://docs.example.com/v2>; rel="deprecation"; type="text/html"`; got != want {
+		t.Errorf("Link = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"pkg/logger"
+)
+
+// Dial creates a connection to another service's gRPC server on the internal network.
+// Calls carry the trace context and, unless already set, the request ID and user of ctx.
+// The connection is established lazily on the first call.
+func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(PropagationInterceptor),
+	}, opts...)
+	return grpc.NewClient(target, opts...)
+}
+
+// WithUserID sets the user a call is made on behalf of, which servers require
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, UserIDKey, userID)
+}
+
+// PropagationInterceptor forwards the request ID and user of ctx as outgoing metadata
+func PropagationInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" && len(md.Get(RequestIDKey)) == 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDKey, requestID)
+	}
+	if userID := logger.UserIDFromContext(ctx); userID != "" && len(md.Get(UserIDKey)) == 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, UserIDKey, userID)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
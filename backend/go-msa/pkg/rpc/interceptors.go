@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"pkg/common_handler"
+	"pkg/logger"
+	"pkg/middleware"
+)
+
+// RequestIDInterceptor accepts a well-formed incoming x-request-id or generates one,
+// stores it in the context for logging and echoes it in the response header
+func RequestIDInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	requestID := incoming(ctx, RequestIDKey)
+	if !middleware.ValidRequestID(requestID) {
+		requestID = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID))
+
+	return handler(logger.ContextWithRequestID(ctx, requestID), req)
+}
+
+// LoggingInterceptor writes an access log entry per call and makes the trace and the calling user
+// available to request-scoped logs
+func LoggingInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		if span := trace.SpanFromContext(ctx); span.SpanContext().HasTraceID() {
+			ctx = logger.ContextWithTraceID(ctx, span.SpanContext().TraceID().String())
+		}
+		if userID := incoming(ctx, UserIDKey); userID != "" {
+			ctx = logger.ContextWithUserID(ctx, userID)
+		}
+
+		resp, err := handler(ctx, req)
+
+		var clientIP string
+		if p, ok := peer.FromContext(ctx); ok {
+			clientIP = p.Addr.String()
+		}
+		log.InfoContext(ctx, "gRPC request",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration", time.Since(start),
+			"client_ip", clientIP,
+			"user_agent", incoming(ctx, "user-agent"),
+		)
+		return resp, err
+	}
+}
+
+// RecoveryInterceptor turns a handler panic into a logged error with its stack trace and an Internal status
+func RecoveryInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			log.ErrorContext(ctx, "Panic recovered",
+				"method", info.FullMethod,
+				"panic", recovered,
+				"stack", string(debug.Stack()),
+			)
+			resp, err = nil, status.Error(codes.Internal, "Internal server error")
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthInterceptor rejects calls without the x-authenticated-user-id metadata.
+// As with HTTP, the caller is trusted to have authenticated the user; the server only sits on the internal network.
+func AuthInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	userID := incoming(ctx, UserIDKey)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+
+	return handler(logger.ContextWithUserID(ctx, userID), req)
+}
+
+// UserID returns the user a call was made on behalf of. AuthInterceptor guarantees it is set.
+func UserID(ctx context.Context) string {
+	return incoming(ctx, UserIDKey)
+}
+
+// ErrorInterceptor maps handler errors to statuses with the same rules errorHandler applies to HTTP responses.
+// Server errors are logged and their cause is hidden from the caller.
+func ErrorInterceptor(errorHandler *common_handler.ErrorHandler, log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		appErr := errorHandler.Resolve(err)
+		if appErr.StatusCode >= http.StatusInternalServerError {
+			log.ErrorContext(ctx, "Request failed",
+				"method", info.FullMethod,
+				"status", appErr.StatusCode,
+				"error", err,
+			)
+		}
+		return nil, Status(appErr).Err()
+	}
+}
+
+// incoming returns the first value of key in the incoming metadata, or ""
+func incoming(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"pkg/common_handler"
+	"pkg/logger"
+)
+
+// Metadata keys mirror the HTTP headers exchanged between the gateway and the services
+const (
+	RequestIDKey = "x-request-id"
+	UserIDKey    = "x-authenticated-user-id"
+)
+
+// Server is a gRPC server meant to run next to a service's HTTP server in the same process.
+// Register its Start and Stop as an app.Hook.
+type Server struct {
+	server *grpc.Server
+	addr   string
+	log    logger.Logger
+}
+
+// NewServer creates a gRPC server listening on addr with the interceptors shared by all services:
+// request IDs, access logs, panic recovery, authentication and errorHandler's error mapping.
+// Incoming trace context is continued, so spans join the caller's trace.
+func NewServer(addr string, log logger.Logger, errorHandler *common_handler.ErrorHandler, opts ...grpc.ServerOption) *Server {
+	opts = append([]grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			RequestIDInterceptor,
+			LoggingInterceptor(log),
+			RecoveryInterceptor(log),
+			AuthInterceptor,
+			ErrorInterceptor(errorHandler, log),
+		),
+	}, opts...)
+
+	return &Server{
+		server: grpc.NewServer(opts...),
+		addr:   addr,
+		log:    log,
+	}
+}
+
+// RegisterService implements grpc.ServiceRegistrar so generated Register functions accept a Server
+func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	s.server.RegisterService(desc, impl)
+}
+
+// Start listens on the server's address and serves in the background
+func (s *Server) Start(context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("Starting gRPC server", "addr", listener.Addr().String())
+	go func() {
+		if err := s.server.Serve(listener); err != nil {
+			s.log.Error("gRPC server stopped with error", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Stop waits for in-flight calls to finish, cancelling them once ctx is done
+func (s *Server) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"pkg/logger"
+	"pkg/router"
+)
+
+// Middleware starts a server span per request, continuing any incoming traceparent,
+// and exposes the trace ID to request-scoped logs.
+// Once routed, the span is renamed after the matched ServeMux pattern so IDs in paths do not explode cardinality;
+// this needs router.RecordRoute around the ServeMux.
+func Middleware(service string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		routed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = router.WithRoute(r)
+			span := trace.SpanFromContext(r.Context())
+			if span.SpanContext().HasTraceID() {
+				r = r.WithContext(logger.ContextWithTraceID(r.Context(), span.SpanContext().TraceID().String()))
+			}
+
+			next.ServeHTTP(w, r)
+
+			if route := router.Route(r); route != "" {
+				span.SetName(route)
+				span.SetAttributes(semconv.HTTPRoute(route))
+			}
+		})
+
+		return otelhttp.NewHandler(routed, service,
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method
+			}),
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				return !strings.HasPrefix(r.URL.Path, "/health")
+			}),
+		)
+	}
+}
+
+// Transport wraps base so outgoing requests get a client span and a traceparent header
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return otelhttp.NewTransport(base)
+}
@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	apperrors "pkg/errors"
+	"pkg/models"
+)
+
+// DefaultMaxBodyBytes JSON 요청 본문 기본 최대 크기 (1MB)
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// DecodeJSON 기본 크기 제한으로 요청 본문을 디코딩하고 태그 규칙으로 검증
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	return DecodeJSONWithLimit(w, r, dst, DefaultMaxBodyBytes)
+}
+
+// DecodeJSONWithLimit 요청 본문을 maxBytes 이내의 단일 JSON 객체로 디코딩하고 검증.
+// 알 수 없는 필드는 거부하며, 모든 실패는 AppError로 반환
+func DecodeJSONWithLimit(w http.ResponseWriter, r *http.Request, dst interface{}, maxBytes int64) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err, maxBytes)
+	}
+
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return decodeError(err, maxBytes)
+		}
+		return apperrors.NewBadRequestError("Request body must contain a single JSON value")
+	}
+
+	return Validate(dst)
+}
+
+// decodeError json 디코더 에러를 클라이언트용 AppError로 변환
+func decodeError(err error, maxBytes int64) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return apperrors.NewBadRequestError("Request body is required")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return apperrors.NewBadRequestError("Request body contains malformed JSON")
+	case errors.As(err, &syntaxErr):
+		return apperrors.NewBadRequestError(fmt.Sprintf("Request body contains malformed JSON at position %d", syntaxErr.Offset))
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return apperrors.NewBadRequestError(fmt.Sprintf("Request body must be a JSON %s", jsonTypeName(typeErr.Type.Kind())))
+		}
+		return apperrors.NewValidationError("Invalid request body", []models.FieldError{
+			{Field: typeErr.Field, Message: fmt.Sprintf("must be a %s", jsonTypeName(typeErr.Type.Kind()))},
+		})
+	case errors.As(err, &maxBytesErr):
+		return apperrors.New(http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE",
+			fmt.Sprintf("Request body must not exceed %d bytes", maxBytes))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return apperrors.NewValidationError("Invalid request body", []models.FieldError{
+			{Field: field, Message: "is not allowed"},
+		})
+	default:
+		return apperrors.NewBadRequestError("Invalid request body")
+	}
+}
+
+// jsonTypeName Go 타입 종류를 JSON 타입 이름으로 변환
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return kind.String()
+	}
+}
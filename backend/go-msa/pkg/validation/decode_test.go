@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apperrors "pkg/errors"
+)
+
+type decodeTarget struct {
+	Name  string `json:"name" validate:"required"`
+	Stock int32  `json:"stock"`
+}
+
+func TestDecodeJSONWithLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+		wantField  string
+	}{
+		{name: "valid", body: `{"name":"shoe","stock":3}`},
+		{name: "empty body", body: ``, wantStatus: http.StatusBadRequest, wantCode: "BAD_REQUEST"},
+		{name: "malformed", body: `{"name":`, wantStatus: http.StatusBadRequest, wantCode: "BAD_REQUEST"},
+		{name: "trailing value", body: `{"name":"shoe"}{}`, wantStatus: http.StatusBadRequest, wantCode: "BAD_REQUEST"},
+		{name: "not an object", body: `[]`, wantStatus: http.StatusBadRequest, wantCode: "BAD_REQUEST"},
+		{name: "unknown field", body: `{"name":"shoe","color":"red"}`, wantStatus: http.StatusBadRequest, wantCode: "VALIDATION_ERROR", wantField: "color"},
+		{name: "wrong type", body: `{"name":"shoe","stock":"3"}`, wantStatus: http.StatusBadRequest, wantCode: "VALIDATION_ERROR", wantField: "stock"},
+		{name: "fails validation", body: `{"stock":3}`, wantStatus: http.StatusBadRequest, wantCode: "VALIDATION_ERROR", wantField: "name"},
+		{name: "too large", body: `{"name":"` + strings.Repeat("a", 64) + `"}`, wantStatus: http.StatusRequestEntityTooLarge, wantCode: "REQUEST_TOO_LARGE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			var dst decodeTarget
+
+			err := DecodeJSONWithLimit(httptest.NewRecorder(), r, &dst, 48)
+
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("DecodeJSONWithLimit() error = %v", err)
+				}
+				if dst.Name != "shoe" || dst.Stock != 3 {
+					t.Errorf("decoded %+v", dst)
+				}
+				return
+			}
+
+			var appErr *apperrors.AppError
+			if !errors.As(err, &appErr) {
+				t.Fatalf("DecodeJSONWithLimit() error = %v, want *AppError", err)
+			}
+			if appErr.StatusCode != tt.wantStatus || appErr.Code != tt.wantCode {
+				t.Errorf("DecodeJSONWithLimit() = %d %s, want %d %s", appErr.StatusCode, appErr.Code, tt.wantStatus, tt.wantCode)
+			}
+			if tt.wantField != "" && (len(appErr.Fields) != 1 || appErr.Fields[0].Field != tt.wantField) {
+				t.Errorf("DecodeJSONWithLimit() fields = %v, want %s", appErr.Fields, tt.wantField)
+			}
+		})
+	}
+}
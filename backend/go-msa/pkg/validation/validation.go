@@ -0,0 +1,253 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	apperrors "pkg/errors"
+	"pkg/models"
+)
+
+// Validator 필드 에러를 모아 하나의 검증 에러로 반환
+//
+// 구조체 태그 규칙 (`validate:"required,max=255"`):
+//   - required: 제로 값이 아니어야 함 (문자열은 공백만 있어도 실패)
+//   - min=N, max=N: 문자열은 글자 수, 숫자는 값, 슬라이스/맵은 길이
+//   - oneof=a b c: 나열된 값 중 하나
+//   - uuid: UUID 형식 문자열
+//   - -: 필드와 그 내용을 검사하지 않음 (슬라이스 항목을 따로 검증할 때 사용)
+type Validator struct {
+	errors []models.FieldError
+}
+
+// New 빈 Validator 생성
+func New() *Validator {
+	return &Validator{}
+}
+
+// Validate 구조체 태그 규칙을 검사하고 위반이 있으면 VALIDATION_ERROR AppError 반환
+func Validate(v interface{}) error {
+	return New().Struct(v).Err()
+}
+
+// Check 조건이 거짓이면 필드 에러 추가. 태그로 표현하기 어려운 규칙에 사용
+func (v *Validator) Check(ok bool, field, message string) *Validator {
+	if !ok {
+		v.AddError(field, message)
+	}
+	return v
+}
+
+// AddError 필드 에러 추가
+func (v *Validator) AddError(field, message string) *Validator {
+	v.errors = append(v.errors, models.FieldError{Field: field, Message: message})
+	return v
+}
+
+// Struct 구조체 태그 규칙 검사. 중첩 구조체와 구조체 슬라이스도 검사
+func (v *Validator) Struct(s interface{}) *Validator {
+	v.validateStruct("", reflect.ValueOf(s))
+	return v
+}
+
+// Valid 지금까지 에러가 없으면 true
+func (v *Validator) Valid() bool {
+	return len(v.errors) == 0
+}
+
+// Errors 수집된 필드 에러 반환
+func (v *Validator) Errors() []models.FieldError {
+	return v.errors
+}
+
+// Err 수집된 필드 에러가 있으면 AppError, 없으면 nil 반환
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return apperrors.NewValidationError("Validation failed", v.errors)
+}
+
+func (v *Validator) validateStruct(prefix string, value reflect.Value) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "-" {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		fieldValue := value.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "-" {
+			continue
+		}
+		if tag != "" {
+			if !v.validateField(name, fieldValue, tag) {
+				continue
+			}
+		}
+
+		v.validateNested(name, fieldValue)
+	}
+}
+
+func (v *Validator) validateNested(name string, value reflect.Value) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		v.validateStruct(name, value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			v.validateNested(fmt.Sprintf("%s[%d]", name, i), value.Index(i))
+		}
+	}
+}
+
+// validateField 첫 번째 위반 규칙만 기록하고, 위반이 있으면 false 반환
+func (v *Validator) validateField(name string, value reflect.Value, tag string) bool {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			if hasRule(tag, "required") {
+				v.AddError(name, "is required")
+				return false
+			}
+			return true
+		}
+		value = value.Elem()
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		key, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if message := checkRule(key, param, value); message != "" {
+			v.AddError(name, message)
+			return false
+		}
+	}
+
+	return true
+}
+
+func checkRule(rule, param string, value reflect.Value) string {
+	switch rule {
+	case "required":
+		if value.IsZero() || (value.Kind() == reflect.String && strings.TrimSpace(value.String()) == "") {
+			return "is required"
+		}
+	case "min", "max":
+		return checkBound(rule, param, value)
+	case "oneof":
+		if value.Kind() == reflect.String && value.String() == "" {
+			return ""
+		}
+		options := strings.Fields(param)
+		actual := fmt.Sprint(value.Interface())
+		for _, option := range options {
+			if option == actual {
+				return ""
+			}
+		}
+		return "must be one of: " + strings.Join(options, ", ")
+	case "uuid":
+		if value.Kind() != reflect.String {
+			panic(fmt.Sprintf("validation: uuid rule on %s", value.Kind()))
+		}
+		if value.String() == "" {
+			return ""
+		}
+		if _, err := uuid.Parse(value.String()); err != nil {
+			return "must be a valid UUID"
+		}
+	default:
+		panic(fmt.Sprintf("validation: unknown rule %q", rule))
+	}
+
+	return ""
+}
+
+func checkBound(rule, param string, value reflect.Value) string {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validation: invalid %s parameter %q", rule, param))
+	}
+
+	var actual float64
+	var unit string
+	switch value.Kind() {
+	case reflect.String:
+		actual = float64(utf8.RuneCountInString(value.String()))
+		unit = "characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		actual = float64(value.Len())
+		unit = "items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = value.Float()
+	default:
+		panic(fmt.Sprintf("validation: %s rule on %s", rule, value.Kind()))
+	}
+
+	if rule == "min" && actual < limit {
+		if unit != "" {
+			return fmt.Sprintf("must contain at least %s %s", param, unit)
+		}
+		return "must be greater than or equal to " + param
+	}
+	if rule == "max" && actual > limit {
+		if unit != "" {
+			return fmt.Sprintf("must contain at most %s %s", param, unit)
+		}
+		return "must be less than or equal to " + param
+	}
+
+	return ""
+}
+
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if strings.TrimSpace(r) == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldName JSON 태그 이름을 우선 사용해 응답의 필드명이 요청 본문과 일치하도록 함
+func fieldName(field reflect.StructField) string {
+	if tag := field.Tag.Get("json"); tag != "" {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,148 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	apperrors "pkg/errors"
+	"pkg/models"
+)
+
+type testAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type testItem struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"min=1,max=10"`
+}
+
+type testRequest struct {
+	Name      string        `json:"name" validate:"required,max=5"`
+	Status    string        `json:"status" validate:"oneof=active inactive"`
+	OwnerID   string        `json:"owner_id" validate:"uuid"`
+	Price     *float64      `json:"price" validate:"required,min=0"`
+	Address   testAddress   `json:"address"`
+	Items     []testItem    `json:"items" validate:"min=1"`
+	Skipped   []testItem    `json:"skipped" validate:"-"`
+	Ignored   string        `json:"-" validate:"required"`
+	internal  string        `validate:"required"`
+	Optional  *testAddress  `json:"optional"`
+	Addresses []testAddress `json:"addresses"`
+}
+
+func validRequest() testRequest {
+	price := 1.5
+	return testRequest{
+		Name:    "shoe",
+		Status:  "active",
+		OwnerID: "1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+		Price:   &price,
+		Address: testAddress{City: "Seoul"},
+		Items:   []testItem{{SKU: "A-1", Quantity: 1}},
+		Skipped: []testItem{{}},
+	}
+}
+
+func TestValidateAcceptsValidStruct(t *testing.T) {
+	request := validRequest()
+	if err := Validate(&request); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
+
+func TestValidateReportsFieldErrors(t *testing.T) {
+	request := validRequest()
+	request.Name = "   "
+	request.Status = "archived"
+	request.OwnerID = "not-a-uuid"
+	request.Price = nil
+	request.Address.City = ""
+	request.Items = []testItem{{SKU: "A-1", Quantity: 11}, {Quantity: 1}}
+	request.Addresses = []testAddress{{City: "Busan"}, {}}
+
+	err := Validate(&request)
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("Validate() error = %v, want *AppError", err)
+	}
+	if appErr.Code != "VALIDATION_ERROR" || appErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Validate() = %s %d, want VALIDATION_ERROR 400", appErr.Code, appErr.StatusCode)
+	}
+
+	want := []models.FieldError{
+		{Field: "name", Message: "is required"},
+		{Field: "status", Message: "must be one of: active, inactive"},
+		{Field: "owner_id", Message: "must be a valid UUID"},
+		{Field: "price", Message: "is required"},
+		{Field: "address.city", Message: "is required"},
+		{Field: "items[0].quantity", Message: "must be less than or equal to 10"},
+		{Field: "items[1].sku", Message: "is required"},
+		{Field: "addresses[1].city", Message: "is required"},
+	}
+	if !reflect.DeepEqual(appErr.Fields, want) {
+		t.Errorf("Validate() fields =\n%v\nwant\n%v", appErr.Fields, want)
+	}
+}
+
+func TestValidateBounds(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "string counts characters", value: struct {
+			V string `validate:"max=3"`
+		}{V: "가나다"}},
+		{name: "string too long", value: struct {
+			V string `validate:"max=3"`
+		}{V: "가나다라"}, want: "must contain at most 3 characters"},
+		{name: "slice too short", value: struct {
+			V []int `validate:"min=2"`
+		}{V: []int{1}}, want: "must contain at least 2 items"},
+		{name: "number too small", value: struct {
+			V float64 `validate:"min=0.5"`
+		}{V: 0.25}, want: "must be greater than or equal to 0.5"},
+		{name: "empty oneof is left to required", value: struct {
+			V string `validate:"oneof=a b"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := New().Struct(tt.value).Errors()
+			switch {
+			case tt.want == "" && len(errs) != 0:
+				t.Errorf("errors = %v, want none", errs)
+			case tt.want != "" && (len(errs) != 1 || errs[0].Message != tt.want):
+				t.Errorf("errors = %v, want [%q]", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatorCheck(t *testing.T) {
+	v := New().Check(true, "a", "unused").Check(false, "b", "must match a")
+
+	if v.Valid() {
+		t.Fatal("Valid() = true after a failed Check")
+	}
+	want := []models.FieldError{{Field: "b", Message: "must match a"}}
+	if !reflect.DeepEqual(v.Errors(), want) {
+		t.Errorf("Errors() = %v, want %v", v.Errors(), want)
+	}
+}
+
+func TestValidatePanicsOnUnknownRule(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Validate() did not panic on an unknown rule")
+		}
+	}()
+	_ = Validate(struct {
+		V string `validate:"email"`
+	}{})
+}
@@ -2,6 +2,12 @@
 
 set -e
 
+# psql은 *_FILE 변수를 지원하지 않으므로 secret 파일에서 PGPASSWORD를 읽습니다.
+if [ -n "$PGPASSWORD_FILE" ]; then
+    PGPASSWORD="$(cat "$PGPASSWORD_FILE")"
+    export PGPASSWORD
+fi
+
 # PG* 환경 변수를 psql이 자동으로 사용하므로 인자를 넘길 필요가 없습니다.
 psql -v ON_ERROR_STOP=1 <<-EOSQL
     -- auth_service 데이터베이스가 없으면 생성
@@ -11,6 +17,10 @@ psql -v ON_ERROR_STOP=1 <<-EOSQL
     -- business_service 데이터베이스가 없으면 생성
     SELECT 'CREATE DATABASE business_service'
     WHERE NOT EXISTS (SELECT FROM pg_database WHERE datname = 'business_service')\gexec
+
+    -- order_service 데이터베이스가 없으면 생성
+    SELECT 'CREATE DATABASE order_service'
+    WHERE NOT EXISTS (SELECT FROM pg_database WHERE datname = 'order_service')\gexec
 EOSQL
 
 echo "Databases are ready."
\ No newline at end of file
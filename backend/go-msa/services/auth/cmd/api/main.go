@@ -2,22 +2,23 @@ package main
 
 import (
 	"auth-service/internal/config"
+	"auth-service/internal/db"
 	"auth-service/internal/handler"
 	"auth-service/internal/repository"
 	"auth-service/internal/service"
 	"context"
-	"errors"
 	"fmt"
-	_ "github.com/lib/pq"
 	"net/http"
 	"os"
-	"os/signal"
+	"pkg/app"
 	"pkg/common_handler"
+	pkgconfig "pkg/config"
 	"pkg/logger"
+	"pkg/metrics"
 	"pkg/middleware"
+	"pkg/openapi"
 	"pkg/router"
-	"syscall"
-	"time"
+	"pkg/telemetry"
 )
 
 const (
@@ -28,63 +29,140 @@ const (
 
 func main() {
 	// Load configuration
-	cfg := config.LoadConfig()
-	log := logger.New(serviceName, cfg.Log.Level)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	log := logger.New(serviceName, cfg.Log.Level, cfg.Log.Format)
+	log.Info("Loaded configuration", "config", cfg.Redacted())
+
+	// Select error response format
+	errorFormat, err := common_handler.ParseErrorFormat(cfg.Server.ErrorFormat)
+	if err != nil {
+		log.Error("Invalid error format", "error", err)
+		os.Exit(1)
+	}
+	common_handler.SetErrorFormat(errorFormat)
+
+	// Setup tracing
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.Config{
+		ServiceName:    serviceName,
+		ServiceVersion: version,
+		Exporter:       cfg.Telemetry.Exporter,
+		OTLPEndpoint:   cfg.Telemetry.OTLPEndpoint,
+		OTLPInsecure:   cfg.Telemetry.OTLPInsecure,
+		SampleRatio:    cfg.Telemetry.SampleRatio,
+	})
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	application := app.New(cfg.Server.AppConfig(), log)
+	application.OnStop("tracing", shutdownTracing)
+
+	// Load secrets
+	secretProvider, err := pkgconfig.NewSecretProvider(cfg.Secrets.Provider, cfg.Secrets.Dir)
+	if err != nil {
+		log.Error("Failed to set up secret provider", "error", err)
+		os.Exit(1)
+	}
+	// Secrets may exist only in the provider, so they are checked once it has been consulted
+	if err := pkgconfig.ResolveSecrets(context.Background(), cfg, secretProvider); err != nil {
+		log.Error("Invalid secrets", "error", err)
+		os.Exit(1)
+	}
+	dbPassword, err := pkgconfig.NewSecret(context.Background(), secretProvider, "DB_PASSWORD", cfg.Database.Password)
+	if err != nil {
+		log.Error("Failed to load database password", "error", err)
+		os.Exit(1)
+	}
+	application.Go("secret-watcher", func(ctx context.Context) {
+		dbPassword.Watch(ctx, cfg.Secrets.RefreshInterval, log)
+	})
+	jwtSecret, err := pkgconfig.NewSecret(context.Background(), secretProvider, "JWT_SECRET", cfg.JWT.Secret)
+	if err != nil {
+		log.Error("Failed to load JWT secret", "error", err)
+		os.Exit(1)
+	}
+	application.Go("secret-watcher", func(ctx context.Context) {
+		jwtSecret.Watch(ctx, cfg.Secrets.RefreshInterval, log)
+	})
 
 	// Connect to database
-	dbConn, err := db.NewConnection(cfg.Database)
+	dbConn, err := db.NewConnection(cfg.Database, dbPassword)
 	if err != nil {
-		log.Error("Failed to connect to database: %v", err)
+		log.Error("Failed to connect to database", "error", err)
 		os.Exit(1)
 	}
-	defer dbConn.Close()
+	application.OnStop("postgres", func(context.Context) error {
+		dbConn.Close()
+		return nil
+	})
 
 	// Initialize services
-	userRepo := repository.NewPostgresUserRepository(db.DB)
-	authService := service.NewAuthService(userRepo)
+	userRepo := repository.NewPostgresUserRepository(dbConn.Queries)
+	authService := service.NewAuthService(userRepo, jwtSecret)
+
+	// Setup metrics
+	registry := metrics.NewRegistry()
+	registry.MustRegister(metrics.NewPoolCollector(dbConn.Pool))
+	httpMetrics := metrics.NewHTTPMetrics(registry)
 
 	// Initialize handlers
 	healthHandler := common_handler.NewHealthHandler(serviceName, version)
+	metricsHandler := common_handler.NewMetricsHandler(registry)
+	healthHandler.AddCheck("postgres", common_handler.PingCheck(dbConn.Pool))
+	healthHandler.AddCheck("migrations", common_handler.MigrationCheck(dbConn.Pool, db.SchemaVersion))
+	errorHandler := common_handler.NewErrorHandler(log)
 	authHandler := handler.NewAuthHandler(authService)
 
 	// Setup routes
-	pathBuilder := router.NewPathBuilder(version, handlerPrefix)
 	mux := http.NewServeMux()
+	versions := router.NewVersions(mux, handlerPrefix)
+	v1 := versions.Mount(version, router.VersionPolicy{})
 	mux.HandleFunc(healthHandler.Path(), healthHandler.Health)
-	mux.HandleFunc(pathBuilder.Path("login"), authHandler.Login)
-	mux.HandleFunc(pathBuilder.Path("register"), authHandler.Register)
+	mux.HandleFunc(healthHandler.LivePath(), healthHandler.Live)
+	mux.HandleFunc(healthHandler.ReadyPath(), healthHandler.Ready)
+	mux.HandleFunc(metricsHandler.Path(), metricsHandler.Metrics)
+	handler.RegisterRoutes(v1, handler.Handlers{
+		Errors: errorHandler,
+		Auth:   authHandler,
+	})
 
-	// Setup server
-	muxHandler := middleware.LoggingMiddleware(log)(mux)
-	addr := fmt.Sprintf(":%s", cfg.Server.Port)
-	server := &http.Server{
-		Addr:    addr,
-		Handler: muxHandler,
+	// Publish the OpenAPI document; routes missing from it are caught by the handler tests
+	spec := handler.NewOpenAPISpec(serviceName, v1)
+	if missing := spec.Undocumented(versions.Patterns()); len(missing) > 0 {
+		log.Warn("Routes missing from the OpenAPI document", "routes", missing)
 	}
+	mux.Handle("GET "+openapi.Path, spec)
 
-	// Start server in a goroutine
-	go func() {
-		log.Info("%s (SQLC + PGX) starting on %s", serviceName, addr)
-		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Error("Server failed to start: %v", err)
-			os.Exit(1)
-		}
-	}()
-
-	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Info("Shutting down server...")
-
-	// Give outstanding requests a deadline for completion
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := server.Shutdown(ctx); err != nil {
-		log.Error("Server forced to shutdown: %v", err)
+	// Setup server
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Error("Invalid trusted proxies", "error", err)
 		os.Exit(1)
 	}
+	muxHandler := middleware.Chain(
+		middleware.RequestIDMiddleware,
+		middleware.RecoveryMiddleware(log),
+		middleware.RealIPMiddleware(trustedProxies),
+		middleware.SecurityHeadersMiddleware,
+		telemetry.Middleware(serviceName),
+		middleware.LoggingMiddleware(log, cfg.Log.AccessLog()),
+		middleware.CompressionMiddleware,
+		middleware.TimeoutMiddleware(cfg.Server.RequestTimeout),
+		middleware.MaxBodySizeMiddleware(cfg.Server.MaxBodyBytes),
+		middleware.MetricsMiddleware(httpMetrics),
+		router.RecordRoute,
+	)(mux)
 
-	log.Info("Server exited")
+	// Run server
+	application.Handle(muxHandler)
+	application.OnShutdown(healthHandler.MarkShuttingDown)
+	if err := application.Run(); err != nil {
+		log.Error("Server stopped with error", "error", err)
+		os.Exit(1)
+	}
 }
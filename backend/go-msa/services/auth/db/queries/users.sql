@@ -0,0 +1,14 @@
+-- name: CreateUser :one
+INSERT INTO users (email, password, created_at, updated_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, email, password, created_at, updated_at;
+
+-- name: GetUserByEmail :one
+SELECT id, email, password, created_at, updated_at
+FROM users
+WHERE email = $1;
+
+-- name: GetUserByID :one
+SELECT id, email, password, created_at, updated_at
+FROM users
+WHERE id = $1;
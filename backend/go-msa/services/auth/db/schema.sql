@@ -0,0 +1,8 @@
+CREATE TABLE users
+(
+    id         UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    email      VARCHAR(255)             NOT NULL UNIQUE,
+    password   VARCHAR(255)             NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -5,9 +5,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	pkgconfig "pkg/config"
+	"pkg/telemetry"
 )
 
+// SchemaVersion is the latest flyway migration this build depends on
+const SchemaVersion = 1
+
 // Connection wraps the database connection and queries
 type Connection struct {
 	Pool    *pgxpool.Pool
@@ -15,7 +21,9 @@ type Connection struct {
 }
 
 // NewConnection creates a new PostgreSQL connection using pgx
-func NewConnection(cfg config.DatabaseConfig) (*Connection, error) {
+// The password is read from the secret for every new connection, so a rotated
+// password applies to the pool without a restart.
+func NewConnection(cfg config.DatabaseConfig, password *pkgconfig.Secret) (*Connection, error) {
 	// Build connection string
 	connStr := fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
@@ -27,8 +35,20 @@ func NewConnection(cfg config.DatabaseConfig) (*Connection, error) {
 		cfg.SSLMode,
 	)
 
+	poolConfig, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse connection string: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = telemetry.NewQueryTracer()
+	if password != nil {
+		poolConfig.BeforeConnect = func(_ context.Context, connConfig *pgx.ConnConfig) error {
+			connConfig.Password = password.Value()
+			return nil
+		}
+	}
+
 	// Create connection pool
-	pool, err := pgxpool.New(context.Background(), connStr)
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"auth-service/internal/service"
+	apperrors "pkg/errors"
+	"pkg/validation"
 )
 
 type AuthHandler struct {
@@ -18,44 +20,41 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 }
 
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" validate:"required,max=255"`
+	Password string `json:"password" validate:"required,max=72"`
 }
 
 type LoginResponse struct {
 	Token string `json:"token"`
 }
 
-func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) error {
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		return err
 	}
 
-	token, err := h.authService.Login(req.Email, req.Password)
+	token, err := h.authService.Login(r.Context(), req.Email, req.Password)
 	if err != nil {
-		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
-		return
+		return apperrors.NewUnauthorizedError("Invalid credentials")
 	}
 
 	resp := LoginResponse{Token: token}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	return json.NewEncoder(w).Encode(resp)
 }
 
-func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) error {
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		return err
 	}
 
-	if err := h.authService.Register(req.Email, req.Password); err != nil {
-		http.Error(w, "Registration failed", http.StatusInternalServerError)
-		return
+	if err := h.authService.Register(r.Context(), req.Email, req.Password); err != nil {
+		return apperrors.NewInternalError("Registration failed", err)
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]string{"message": "User created successfully"})
+	return json.NewEncoder(w).Encode(map[string]string{"message": "User created successfully"})
 }
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"pkg/openapi"
+	"pkg/router"
+)
+
+// NewOpenAPISpec documents every auth-service route registered on v1
+func NewOpenAPISpec(title string, v1 *router.VersionedRoutes) *openapi.Spec {
+	spec := openapi.New(title, v1.Version())
+	tags := []string{"auth"}
+
+	spec.Add(v1.Pattern("POST login"), openapi.Endpoint{
+		Summary:  "Exchange credentials for a JWT",
+		Tags:     tags,
+		Request:  LoginRequest{},
+		Response: LoginResponse{},
+		Errors:   []int{http.StatusUnauthorized},
+		Public:   true,
+	})
+	spec.Add(v1.Pattern("POST register"), openapi.Endpoint{
+		Summary:  "Create a user account",
+		Tags:     tags,
+		Request:  LoginRequest{},
+		Response: map[string]string{},
+		Status:   http.StatusCreated,
+		Public:   true,
+	})
+
+	return spec
+}
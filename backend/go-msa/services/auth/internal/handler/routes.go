@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"pkg/common_handler"
+	"pkg/router"
+)
+
+// Handlers are the handlers served under the versioned API routes
+type Handlers struct {
+	Errors *common_handler.ErrorHandler
+	Auth   *AuthHandler
+}
+
+// RegisterRoutes registers every auth-service API route on v1.
+// Each route must also be documented in NewOpenAPISpec.
+func RegisterRoutes(v1 *router.VersionedRoutes, h Handlers) {
+	v1.HandleFunc("login", h.Errors.Handle(h.Auth.Login))
+	v1.HandleFunc("register", h.Errors.Handle(h.Auth.Register))
+}
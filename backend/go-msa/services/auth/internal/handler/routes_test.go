@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"pkg/common_handler"
+	"pkg/logger"
+	"pkg/router"
+)
+
+func TestRoutesAreDocumented(t *testing.T) {
+	log := logger.NewWithWriters("auth-service", "error", "json", io.Discard, io.Discard)
+
+	versions := router.NewVersions(http.NewServeMux(), "auth")
+	v1 := versions.Mount("v1", router.VersionPolicy{})
+	RegisterRoutes(v1, Handlers{
+		Errors: common_handler.NewErrorHandler(log),
+		Auth:   NewAuthHandler(nil),
+	})
+
+	spec := NewOpenAPISpec("auth-service", v1)
+	if missing := spec.Undocumented(versions.Patterns()); len(missing) > 0 {
+		t.Errorf("routes missing from the OpenAPI document: %v", missing)
+	}
+}
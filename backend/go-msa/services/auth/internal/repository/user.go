@@ -1,73 +1,64 @@
 package repository
 
 import (
-	"database/sql"
+	"context"
+	"fmt"
 
+	"auth-service/internal/db"
 	"auth-service/internal/domain"
-	_ "github.com/lib/pq"
+	"github.com/google/uuid"
 )
 
 type PostgresUserRepository struct {
-	db *sql.DB
+	queries *db.Queries
 }
 
-func NewPostgresUserRepository(db *sql.DB) *PostgresUserRepository {
-	return &PostgresUserRepository{db: db}
+func NewPostgresUserRepository(queries *db.Queries) *PostgresUserRepository {
+	return &PostgresUserRepository{queries: queries}
 }
 
-func (r *PostgresUserRepository) Create(user *domain.User) error {
-	query := `
-		INSERT INTO users (email, password, created_at, updated_at)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id
-	`
+func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User) error {
+	created, err := r.queries.CreateUser(ctx, db.CreateUserParams{
+		Email:     user.Email,
+		Password:  user.Password,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
 
-	err := r.db.QueryRow(query, user.Email, user.Password, user.CreatedAt, user.UpdatedAt).Scan(&user.ID)
-	return err
+	user.ID = created.ID.String()
+	return nil
 }
 
-func (r *PostgresUserRepository) FindByEmail(email string) (*domain.User, error) {
-	query := `
-		SELECT id, email, password, created_at, updated_at
-		FROM users
-		WHERE email = $1
-	`
-
-	user := &domain.User{}
-	err := r.db.QueryRow(query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Password,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user, err := r.queries.GetUserByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
-
-	return user, nil
+	return toDomainUser(user), nil
 }
 
-func (r *PostgresUserRepository) FindByID(id string) (*domain.User, error) {
-	query := `
-		SELECT id, email, password, created_at, updated_at
-		FROM users
-		WHERE id = $1
-	`
-
-	user := &domain.User{}
-	err := r.db.QueryRow(query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Password,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
 
+	user, err := r.queries.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	return toDomainUser(user), nil
+}
 
-	return user, nil
+func toDomainUser(user *db.User) *domain.User {
+	return &domain.User{
+		ID:        user.ID.String(),
+		Email:     user.Email,
+		Password:  user.Password,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
 }
@@ -1,25 +1,36 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"auth-service/internal/domain"
 	"golang.org/x/crypto/bcrypt"
+	"pkg/config"
+	"pkg/telemetry"
 )
 
+var tracer = telemetry.Tracer("auth-service/internal/service")
+
 type AuthService struct {
-	userRepo domain.UserRepository
+	userRepo   domain.UserRepository
+	signingKey *config.Secret
 }
 
-func NewAuthService(userRepo domain.UserRepository) *AuthService {
+// NewAuthService creates an AuthService. The signing key is read on every token
+// issue, so a rotated JWT secret takes effect without a restart.
+func NewAuthService(userRepo domain.UserRepository, signingKey *config.Secret) *AuthService {
 	return &AuthService{
-		userRepo: userRepo,
+		userRepo:   userRepo,
+		signingKey: signingKey,
 	}
 }
 
-func (a *AuthService) Register(email, password string) error {
+func (a *AuthService) Register(ctx context.Context, email, password string) error {
+	_, span := tracer.Start(ctx, "bcrypt.GenerateFromPassword")
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	span.End()
 	if err != nil {
 		return err
 	}
@@ -31,16 +42,19 @@ func (a *AuthService) Register(email, password string) error {
 		UpdatedAt: time.Now(),
 	}
 
-	return a.userRepo.Create(user)
+	return a.userRepo.Create(ctx, user)
 }
 
-func (a *AuthService) Login(email, password string) (string, error) {
-	user, err := a.userRepo.FindByEmail(email)
+func (a *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+	user, err := a.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return "", err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+	_, span := tracer.Start(ctx, "bcrypt.CompareHashAndPassword")
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	span.End()
+	if err != nil {
 		return "", errors.New("invalid credentials")
 	}
 
@@ -53,5 +67,8 @@ func (a *AuthService) Login(email, password string) (string, error) {
 }
 
 func (a *AuthService) generateJWT(user *domain.User) (string, error) {
+	if a.signingKey == nil || a.signingKey.Value() == "" {
+		return "", errors.New("jwt signing key is not configured")
+	}
 	return "jwt-token-placeholder", nil
 }
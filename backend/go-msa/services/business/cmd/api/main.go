@@ -2,22 +2,26 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
 	"pkg/middleware"
 	"pkg/router"
-	"syscall"
-	"time"
 
 	"business-service/internal/config"
 	"business-service/internal/db"
 	"business-service/internal/handler"
+	"business-service/internal/outbox"
 	"business-service/internal/service"
+	"pkg/app"
 	"pkg/common_handler"
+	pkgconfig "pkg/config"
 	"pkg/logger"
+	"pkg/metrics"
+	"pkg/openapi"
+	businessv1 "pkg/proto/business/v1"
+	"pkg/rpc"
+	"pkg/telemetry"
 )
 
 const (
@@ -28,79 +32,176 @@ const (
 
 func main() {
 	// Load configuration
-	cfg := config.LoadConfig()
-	log := logger.New(serviceName, cfg.Log.Level)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	log := logger.New(serviceName, cfg.Log.Level, cfg.Log.Format)
+	log.Info("Loaded configuration", "config", cfg.Redacted())
+
+	// Select error response format
+	errorFormat, err := common_handler.ParseErrorFormat(cfg.Server.ErrorFormat)
+	if err != nil {
+		log.Error("Invalid error format", "error", err)
+		os.Exit(1)
+	}
+	common_handler.SetErrorFormat(errorFormat)
+
+	// Setup tracing
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.Config{
+		ServiceName:    serviceName,
+		ServiceVersion: version,
+		Exporter:       cfg.Telemetry.Exporter,
+		OTLPEndpoint:   cfg.Telemetry.OTLPEndpoint,
+		OTLPInsecure:   cfg.Telemetry.OTLPInsecure,
+		SampleRatio:    cfg.Telemetry.SampleRatio,
+	})
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	application := app.New(cfg.Server.AppConfig(), log)
+	application.OnStop("tracing", shutdownTracing)
+
+	// Load secrets
+	secretProvider, err := pkgconfig.NewSecretProvider(cfg.Secrets.Provider, cfg.Secrets.Dir)
+	if err != nil {
+		log.Error("Failed to set up secret provider", "error", err)
+		os.Exit(1)
+	}
+	// Secrets may exist only in the provider, so they are checked once it has been consulted
+	if err := pkgconfig.ResolveSecrets(context.Background(), cfg, secretProvider); err != nil {
+		log.Error("Invalid secrets", "error", err)
+		os.Exit(1)
+	}
+	dbPassword, err := pkgconfig.NewSecret(context.Background(), secretProvider, "DB_PASSWORD", cfg.Database.Password)
+	if err != nil {
+		log.Error("Failed to load database password", "error", err)
+		os.Exit(1)
+	}
+	application.Go("secret-watcher", func(ctx context.Context) {
+		dbPassword.Watch(ctx, cfg.Secrets.RefreshInterval, log)
+	})
 
 	// Connect to database
-	dbConn, err := db.NewConnection(cfg.Database)
+	dbConn, err := db.NewConnection(cfg.Database, dbPassword)
 	if err != nil {
-		log.Error("Failed to connect to database: %v", err)
+		log.Error("Failed to connect to database", "error", err)
 		os.Exit(1)
 	}
-	defer dbConn.Close()
+	application.OnStop("postgres", func(context.Context) error {
+		dbConn.Close()
+		return nil
+	})
 
 	// Initialize services
-	productService := service.NewProductService(dbConn.Queries)
+	productService := service.NewProductService(dbConn, cfg.Business.BatchChunkSize)
+	stockService := service.NewStockService(dbConn, cfg.Stock.DefaultReservationTTL, cfg.Stock.MaxReservationTTL)
+	inventoryService := service.NewInventoryService(dbConn)
+	importService := service.NewProductImportService(dbConn, cfg.Business.BatchChunkSize, cfg.Import.MaxErrors, cfg.Import.MaxAttempts, cfg.Import.StaleAfter, log)
+
+	// Setup metrics
+	registry := metrics.NewRegistry()
+	registry.MustRegister(metrics.NewPoolCollector(dbConn.Pool))
+	httpMetrics := metrics.NewHTTPMetrics(registry)
 
 	// Initialize handlers
 	healthHandler := common_handler.NewHealthHandler(serviceName, version)
+	metricsHandler := common_handler.NewMetricsHandler(registry)
+	healthHandler.AddCheck("postgres", common_handler.PingCheck(dbConn.Pool))
+	healthHandler.AddCheck("migrations", common_handler.MigrationCheck(dbConn.Pool, db.SchemaVersion))
+	errorHandler := handler.NewErrorHandler(log)
 	productHandler := handler.NewProductHandler(productService)
+	productBatchHandler := handler.NewProductBatchHandler(productService, errorHandler, log, cfg.Business.MaxBatchItems, cfg.Server.MaxBodyBytes)
+	productExportHandler := handler.NewProductExportHandler(productService, log)
+	productImportHandler := handler.NewProductImportHandler(importService, cfg.Import.MaxBytes)
+	stockHandler := handler.NewStockHandler(stockService)
+	inventoryHandler := handler.NewInventoryHandler(inventoryService, cfg.Business.MaxProductsPerPage)
 
 	// Setup routes
-	pathBuilder := router.NewPathBuilder(version, handlerPrefix)
 	mux := http.NewServeMux()
+	versions := router.NewVersions(mux, handlerPrefix)
+	v1 := versions.Mount(version, router.VersionPolicy{})
 	mux.HandleFunc(healthHandler.Path(), healthHandler.Health)
-	mux.HandleFunc(pathBuilder.Path("products"), func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case "GET":
-			// Check if it's a price range query
-			if r.URL.Query().Get("min_price") != "" && r.URL.Query().Get("max_price") != "" {
-				productHandler.GetProductsByPriceRange(w, r)
-			} else {
-				productHandler.GetProducts(w, r)
-			}
-		case "POST":
-			productHandler.CreateProduct(w, r)
-		case "PUT":
-			productHandler.UpdateProduct(w, r)
-		case "DELETE":
-			productHandler.DeleteProduct(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	mux.HandleFunc(healthHandler.LivePath(), healthHandler.Live)
+	mux.HandleFunc(healthHandler.ReadyPath(), healthHandler.Ready)
+	mux.HandleFunc(metricsHandler.Path(), metricsHandler.Metrics)
+	handler.RegisterRoutes(v1, handler.Handlers{
+		Errors:        errorHandler,
+		Product:       productHandler,
+		ProductBatch:  productBatchHandler,
+		ProductExport: productExportHandler,
+		ProductImport: productImportHandler,
+		Stock:         stockHandler,
+		Inventory:     inventoryHandler,
 	})
 
-	// Setup server
-	muxHandler := middleware.LoggingMiddleware(log)(mux)
-	addr := fmt.Sprintf(":%d", cfg.Server.Port)
-	server := &http.Server{
-		Addr:    addr,
-		Handler: muxHandler,
+	// Publish the OpenAPI document; routes missing from it are caught by the handler tests
+	spec := handler.NewOpenAPISpec(serviceName, v1)
+	if missing := spec.Undocumented(versions.Patterns()); len(missing) > 0 {
+		log.Warn("Routes missing from the OpenAPI document", "routes", missing)
 	}
+	mux.Handle("GET "+openapi.Path, spec)
+
+	// Serve ProductService over gRPC on its own port for internal callers
+	grpcServer := rpc.NewServer(cfg.Server.GRPCAddr(), log, errorHandler)
+	businessv1.RegisterProductServiceServer(grpcServer, handler.NewProductGRPCServer(productService, stockService, cfg.Business.MaxBatchGetProducts))
+	application.Hook(app.Hook{Name: "grpc-server", Start: grpcServer.Start, Stop: grpcServer.Stop})
+
+	// Register background workers
+	sweeper := service.NewReservationSweeper(stockService, cfg.Stock.SweepInterval, cfg.Stock.SweepBatchSize, log)
+	application.Go("reservation-sweeper", sweeper.Run)
 
-	// Start server in a goroutine
-	go func() {
-		log.Info("%s (SQLC + PGX) starting on %s", serviceName, addr)
-		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Error("Server failed to start: %v", err)
-			os.Exit(1)
-		}
-	}()
-
-	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Info("Shutting down server...")
-
-	// Give outstanding requests a deadline for completion
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := server.Shutdown(ctx); err != nil {
-		log.Error("Server forced to shutdown: %v", err)
+	importer := service.NewProductImporter(importService, cfg.Import.PollInterval, log)
+	application.Go("product-importer", importer.Run)
+
+	publisher, err := outbox.NewPublisher(context.Background(), cfg.Outbox, dbConn)
+	if err != nil {
+		log.Error("Failed to create outbox publisher", "error", err)
 		os.Exit(1)
 	}
+	application.OnStop("outbox-publisher", func(context.Context) error {
+		return publisher.Close()
+	})
+
+	relay := outbox.NewRelay(dbConn, publisher, cfg.Outbox, log)
+	application.Go("outbox-relay", relay.Run)
 
-	log.Info("Server exited")
+	// Setup server
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Error("Invalid trusted proxies", "error", err)
+		os.Exit(1)
+	}
+	muxHandler := middleware.Chain(
+		middleware.RequestIDMiddleware,
+		middleware.RecoveryMiddleware(log),
+		middleware.RealIPMiddleware(trustedProxies),
+		middleware.SecurityHeadersMiddleware,
+		telemetry.Middleware(serviceName),
+		middleware.LoggingMiddleware(log, cfg.Log.AccessLog()),
+		middleware.CompressionMiddleware,
+		// A full export streams for longer than a regular request, so it is bounded by EXPORT_TIMEOUT instead
+		middleware.TimeoutMiddleware(cfg.Server.RequestTimeout, middleware.TimeoutOverride{
+			Pattern: "GET /{version}/" + handlerPrefix + "/products/export",
+			Timeout: cfg.Business.ExportTimeout,
+		}),
+		// Import files are limited by IMPORT_MAX_BYTES in their handler instead
+		middleware.MaxBodySizeMiddleware(cfg.Server.MaxBodyBytes, middleware.BodyLimitOverride{
+			Pattern:  "POST /{version}/" + handlerPrefix + "/products/imports",
+			MaxBytes: cfg.Import.MaxBytes,
+		}),
+		middleware.MetricsMiddleware(httpMetrics),
+		router.RecordRoute,
+	)(mux)
+
+	// Run server
+	application.Handle(muxHandler)
+	application.OnShutdown(healthHandler.MarkShuttingDown)
+	if err := application.Run(); err != nil {
+		log.Error("Server stopped with error", "error", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,14 @@
+CREATE TABLE stock_reservations
+(
+    id         UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    product_id UUID                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    quantity   INTEGER                  NOT NULL CHECK (quantity > 0),
+    status     VARCHAR(20)              NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'released', 'expired')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_reservations_product_id ON stock_reservations (product_id);
+CREATE INDEX idx_stock_reservations_pending_expires_at ON stock_reservations (expires_at) WHERE status = 'pending';
@@ -0,0 +1,32 @@
+CREATE TABLE inventory_movements
+(
+    id           UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    product_id   UUID                     NOT NULL,
+    delta        INTEGER                  NOT NULL CHECK (delta <> 0),
+    reason       VARCHAR(50)              NOT NULL,
+    actor        VARCHAR(255)             NOT NULL,
+    reference_id VARCHAR(255),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_movements_product_id_created_at ON inventory_movements (product_id, created_at);
+
+-- The ledger is append-only: corrections are recorded as new movements
+CREATE FUNCTION reject_inventory_movement_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'inventory_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_inventory_movements_append_only
+    BEFORE UPDATE OR DELETE
+    ON inventory_movements
+    FOR EACH ROW
+EXECUTE FUNCTION reject_inventory_movement_change();
+
+-- Seed the ledger with the stock that existed before it was introduced
+INSERT INTO inventory_movements (product_id, delta, reason, actor)
+SELECT id, stock, 'opening_balance', 'system'
+FROM products
+WHERE stock <> 0;
@@ -0,0 +1,16 @@
+CREATE TABLE outbox
+(
+    id             UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    seq            BIGSERIAL                NOT NULL UNIQUE,
+    aggregate_type VARCHAR(50)              NOT NULL,
+    aggregate_id   UUID                     NOT NULL,
+    event_type     VARCHAR(100)             NOT NULL,
+    payload        JSONB                    NOT NULL,
+    attempts       INTEGER                  NOT NULL DEFAULT 0,
+    last_error     TEXT,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    published_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_outbox_pending_seq ON outbox (seq) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_published_at ON outbox (published_at) WHERE published_at IS NOT NULL;
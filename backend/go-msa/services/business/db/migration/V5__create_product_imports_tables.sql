@@ -0,0 +1,34 @@
+-- External SKUs let spreadsheet imports address products without knowing their IDs
+CREATE TABLE product_skus
+(
+    external_sku VARCHAR(100) PRIMARY KEY,
+    product_id   UUID         NOT NULL UNIQUE REFERENCES products (id) ON DELETE CASCADE
+);
+
+CREATE TABLE product_import_jobs
+(
+    id             UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    status         VARCHAR(20)              NOT NULL DEFAULT 'pending',
+    format         VARCHAR(20)              NOT NULL,
+    actor          VARCHAR(255)             NOT NULL,
+    attempts       INTEGER                  NOT NULL DEFAULT 0,
+    processed_rows INTEGER                  NOT NULL DEFAULT 0,
+    created_rows   INTEGER                  NOT NULL DEFAULT 0,
+    updated_rows   INTEGER                  NOT NULL DEFAULT 0,
+    failed_rows    INTEGER                  NOT NULL DEFAULT 0,
+    errors         JSONB                    NOT NULL DEFAULT '[]',
+    last_error     TEXT,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at     TIMESTAMP WITH TIME ZONE,
+    finished_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_product_import_jobs_active_created_at ON product_import_jobs (created_at) WHERE status IN ('pending', 'running');
+
+-- Uploaded files are kept apart from the job so status reads stay small; they are deleted once the job finishes
+CREATE TABLE product_import_payloads
+(
+    job_id UUID PRIMARY KEY REFERENCES product_import_jobs (id) ON DELETE CASCADE,
+    data   BYTEA NOT NULL
+);
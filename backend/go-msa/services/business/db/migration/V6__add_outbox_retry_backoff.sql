@@ -0,0 +1,6 @@
+-- Events that fail to publish wait out a backoff instead of staying at the head of the queue
+ALTER TABLE outbox
+    ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+-- Looks up earlier pending events of the same aggregate
+CREATE INDEX idx_outbox_pending_aggregate_seq ON outbox (aggregate_id, seq) WHERE published_at IS NULL;
@@ -0,0 +1,27 @@
+-- name: CreateInventoryMovement :one
+INSERT INTO inventory_movements (product_id, delta, reason, actor, reference_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, product_id, delta, reason, actor, reference_id, created_at;
+
+-- name: ListInventoryMovements :many
+SELECT id, product_id, delta, reason, actor, reference_id, created_at
+FROM inventory_movements
+WHERE product_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetLedgerStock :one
+SELECT COALESCE(SUM(delta), 0)::int AS ledger_stock
+FROM inventory_movements
+WHERE product_id = $1;
+
+-- name: ListStockDiscrepancies :many
+SELECT p.id, p.stock, p.created_at, COALESCE(SUM(m.delta), 0)::int AS ledger_stock
+FROM products p
+         LEFT JOIN inventory_movements m ON m.product_id = p.id
+WHERE p.created_at > sqlc.arg(after_created_at)
+   OR (p.created_at = sqlc.arg(after_created_at) AND p.id > sqlc.arg(after_id))
+GROUP BY p.id, p.stock, p.created_at
+HAVING p.stock <> COALESCE(SUM(m.delta), 0)
+ORDER BY p.created_at, p.id
+LIMIT sqlc.arg(row_limit)::int;
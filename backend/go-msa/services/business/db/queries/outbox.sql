@@ -0,0 +1,37 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING id, seq, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, created_at, published_at, next_attempt_at;
+
+-- name: TryOutboxRelayLock :one
+SELECT pg_try_advisory_xact_lock(sqlc.arg(lock_key)::bigint) AS acquired;
+
+-- name: ListPendingOutboxEvents :many
+-- An event is held back while it, or an earlier event of its aggregate, waits out a retry backoff
+SELECT o.id, o.seq, o.aggregate_type, o.aggregate_id, o.event_type, o.payload, o.attempts, o.last_error, o.created_at, o.published_at, o.next_attempt_at
+FROM outbox o
+WHERE o.published_at IS NULL
+  AND NOT EXISTS (SELECT 1
+                  FROM outbox b
+                  WHERE b.aggregate_id = o.aggregate_id
+                    AND b.published_at IS NULL
+                    AND b.seq <= o.seq
+                    AND b.next_attempt_at > NOW())
+ORDER BY o.seq
+LIMIT $1;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox
+SET published_at = NOW(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: RecordOutboxEventFailure :exec
+UPDATE outbox
+SET attempts        = attempts + 1,
+    last_error      = $2,
+    next_attempt_at = NOW() + make_interval(secs => sqlc.arg(backoff_seconds)::int)
+WHERE id = $1;
+
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox
+WHERE published_at < NOW() - make_interval(secs => sqlc.arg(retention_seconds)::int);
@@ -0,0 +1,67 @@
+-- name: CreateProductImportJob :one
+INSERT INTO product_import_jobs (format, actor)
+VALUES ($1, $2)
+RETURNING id, status, format, actor, attempts, processed_rows, created_rows, updated_rows, failed_rows, errors, last_error, created_at, updated_at, started_at, finished_at;
+
+-- name: CreateProductImportPayload :exec
+INSERT INTO product_import_payloads (job_id, data)
+VALUES ($1, $2);
+
+-- name: GetProductImportJob :one
+SELECT id, status, format, actor, attempts, processed_rows, created_rows, updated_rows, failed_rows, errors, last_error, created_at, updated_at, started_at, finished_at
+FROM product_import_jobs
+WHERE id = $1;
+
+-- name: ClaimProductImportJob :one
+UPDATE product_import_jobs
+SET status = 'running', attempts = attempts + 1, started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+WHERE id = (
+    SELECT j.id
+    FROM product_import_jobs j
+    WHERE j.status = 'pending'
+       OR (j.status = 'running' AND j.updated_at < NOW() - make_interval(secs => sqlc.arg(stale_seconds)::int))
+    ORDER BY j.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, status, format, actor, attempts, processed_rows, created_rows, updated_rows, failed_rows, errors, last_error, created_at, updated_at, started_at, finished_at;
+
+-- name: GetProductImportPayload :one
+SELECT data
+FROM product_import_payloads
+WHERE job_id = $1;
+
+-- name: RecordProductImportProgress :execrows
+-- Only the worker holding the job's current attempt may record progress; a job taken over as stale is fenced off
+UPDATE product_import_jobs
+SET processed_rows = processed_rows + sqlc.arg(processed)::int,
+    created_rows   = created_rows + sqlc.arg(created)::int,
+    updated_rows   = updated_rows + sqlc.arg(updated)::int,
+    failed_rows    = failed_rows + sqlc.arg(failed)::int,
+    errors         = errors || sqlc.arg(errors)::jsonb,
+    updated_at     = NOW()
+WHERE id = sqlc.arg(id)
+  AND attempts = sqlc.arg(attempts)
+  AND status = 'running';
+
+-- name: FinishProductImportJob :execrows
+-- Fenced like RecordProductImportProgress
+UPDATE product_import_jobs
+SET status = $2, last_error = $3, finished_at = NOW(), updated_at = NOW()
+WHERE id = $1
+  AND attempts = $4
+  AND status = 'running';
+
+-- name: DeleteProductImportPayload :exec
+DELETE FROM product_import_payloads
+WHERE job_id = $1;
+
+-- name: GetProductIDBySKUForUpdate :one
+SELECT product_id
+FROM product_skus
+WHERE external_sku = $1
+FOR UPDATE;
+
+-- name: CreateProductSKU :exec
+INSERT INTO product_skus (external_sku, product_id)
+VALUES ($1, $2);
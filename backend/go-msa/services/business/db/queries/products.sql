@@ -3,6 +3,11 @@ SELECT id, name, description, price, stock, created_at, updated_at
 FROM products
 WHERE id = $1;
 
+-- name: GetProductsByIDs :many
+SELECT id, name, description, price, stock, created_at, updated_at
+FROM products
+WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
 -- name: ListProducts :many
 SELECT id, name, description, price, stock, created_at, updated_at
 FROM products
@@ -33,4 +38,21 @@ ORDER BY price ASC;
 UPDATE products
 SET stock = $2
 WHERE id = $1
-RETURNING id, name, description, price, stock, created_at, updated_at;
\ No newline at end of file
+RETURNING id, name, description, price, stock, created_at, updated_at;
+
+-- name: AdjustProductStock :one
+UPDATE products
+SET stock = stock + sqlc.arg(delta)::int, updated_at = NOW()
+WHERE id = sqlc.arg(id) AND stock + sqlc.arg(delta)::int >= 0
+RETURNING id, name, description, price, stock, created_at, updated_at;
+
+-- name: GetProductForUpdate :one
+SELECT id, name, description, price, stock, created_at, updated_at
+FROM products
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CreateProductWithID :one
+INSERT INTO products (id, name, description, price, stock)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, description, price, stock, created_at, updated_at;
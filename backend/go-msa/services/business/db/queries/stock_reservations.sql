@@ -0,0 +1,34 @@
+-- name: CreateStockReservation :one
+INSERT INTO stock_reservations (product_id, quantity, expires_at)
+VALUES (sqlc.arg(product_id), sqlc.arg(quantity), NOW() + make_interval(secs => sqlc.arg(ttl_seconds)::int))
+RETURNING id, product_id, quantity, status, expires_at, created_at, updated_at;
+
+-- name: GetStockReservation :one
+SELECT id, product_id, quantity, status, expires_at, created_at, updated_at
+FROM stock_reservations
+WHERE id = $1;
+
+-- name: ConfirmStockReservation :one
+UPDATE stock_reservations
+SET status = 'confirmed', updated_at = NOW()
+WHERE id = $1 AND status = 'pending' AND expires_at > NOW()
+RETURNING id, product_id, quantity, status, expires_at, created_at, updated_at;
+
+-- name: ReleaseStockReservation :one
+UPDATE stock_reservations
+SET status = 'released', updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING id, product_id, quantity, status, expires_at, created_at, updated_at;
+
+-- name: ExpireStockReservations :many
+UPDATE stock_reservations
+SET status = 'expired', updated_at = NOW()
+WHERE id IN (
+    SELECT sr.id
+    FROM stock_reservations sr
+    WHERE sr.status = 'pending' AND sr.expires_at <= NOW()
+    ORDER BY sr.expires_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, product_id, quantity, status, expires_at, created_at, updated_at;
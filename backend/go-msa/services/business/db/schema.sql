@@ -11,3 +11,100 @@ CREATE TABLE products
 
 CREATE INDEX idx_products_name ON products (name);
 CREATE INDEX idx_products_created_at ON products (created_at);
+
+CREATE TABLE stock_reservations
+(
+    id         UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    product_id UUID                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    quantity   INTEGER                  NOT NULL CHECK (quantity > 0),
+    status     VARCHAR(20)              NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'released', 'expired')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_reservations_product_id ON stock_reservations (product_id);
+CREATE INDEX idx_stock_reservations_pending_expires_at ON stock_reservations (expires_at) WHERE status = 'pending';
+
+CREATE TABLE inventory_movements
+(
+    id           UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    product_id   UUID                     NOT NULL,
+    delta        INTEGER                  NOT NULL CHECK (delta <> 0),
+    reason       VARCHAR(50)              NOT NULL,
+    actor        VARCHAR(255)             NOT NULL,
+    reference_id VARCHAR(255),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_movements_product_id_created_at ON inventory_movements (product_id, created_at);
+
+-- The ledger is append-only: corrections are recorded as new movements
+CREATE FUNCTION reject_inventory_movement_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'inventory_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_inventory_movements_append_only
+    BEFORE UPDATE OR DELETE
+    ON inventory_movements
+    FOR EACH ROW
+EXECUTE FUNCTION reject_inventory_movement_change();
+
+CREATE TABLE outbox
+(
+    id              UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    seq             BIGSERIAL                NOT NULL UNIQUE,
+    aggregate_type  VARCHAR(50)              NOT NULL,
+    aggregate_id    UUID                     NOT NULL,
+    event_type      VARCHAR(100)             NOT NULL,
+    payload         JSONB                    NOT NULL,
+    attempts        INTEGER                  NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    published_at    TIMESTAMP WITH TIME ZONE,
+    -- Events that fail to publish wait out a backoff instead of staying at the head of the queue
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_outbox_pending_seq ON outbox (seq) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_pending_aggregate_seq ON outbox (aggregate_id, seq) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_published_at ON outbox (published_at) WHERE published_at IS NOT NULL;
+
+-- External SKUs let spreadsheet imports address products without knowing their IDs
+CREATE TABLE product_skus
+(
+    external_sku VARCHAR(100) PRIMARY KEY,
+    product_id   UUID         NOT NULL UNIQUE REFERENCES products (id) ON DELETE CASCADE
+);
+
+CREATE TABLE product_import_jobs
+(
+    id             UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    status         VARCHAR(20)              NOT NULL DEFAULT 'pending',
+    format         VARCHAR(20)              NOT NULL,
+    actor          VARCHAR(255)             NOT NULL,
+    attempts       INTEGER                  NOT NULL DEFAULT 0,
+    processed_rows INTEGER                  NOT NULL DEFAULT 0,
+    created_rows   INTEGER                  NOT NULL DEFAULT 0,
+    updated_rows   INTEGER                  NOT NULL DEFAULT 0,
+    failed_rows    INTEGER                  NOT NULL DEFAULT 0,
+    errors         JSONB                    NOT NULL DEFAULT '[]',
+    last_error     TEXT,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at     TIMESTAMP WITH TIME ZONE,
+    finished_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_product_import_jobs_active_created_at ON product_import_jobs (created_at) WHERE status IN ('pending', 'running');
+
+-- Uploaded files are kept apart from the job so status reads stay small; they are deleted once the job finishes
+CREATE TABLE product_import_payloads
+(
+    job_id UUID PRIMARY KEY REFERENCES product_import_jobs (id) ON DELETE CASCADE,
+    data   BYTEA NOT NULL
+);
@@ -2,65 +2,172 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"pkg/app"
 	"pkg/config"
+	"pkg/middleware"
 )
 
 // Config holds all configuration for the business service
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Business BusinessConfig
-	Log      LogConfig
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Secrets   SecretsConfig   `yaml:"secrets"`
+	Business  BusinessConfig  `yaml:"business"`
+	Stock     StockConfig     `yaml:"stock"`
+	Import    ImportConfig    `yaml:"import"`
+	Outbox    OutboxConfig    `yaml:"outbox"`
+	Telemetry TelemetryConfig `yaml:"telemetry"`
+	Log       LogConfig       `yaml:"log"`
 }
 
 type ServerConfig struct {
-	Port int
-	Host string
+	Port              int           `yaml:"port" env:"PORT" default:"8080"`
+	Host              string        `yaml:"host" env:"HOST" default:"0.0.0.0"`
+	ErrorFormat       string        `yaml:"error_format" env:"ERROR_FORMAT" default:"json"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT" default:"5s"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT" default:"15s"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT" default:"30s"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" default:"60s"`
+	DrainPeriod       time.Duration `yaml:"drain_period" env:"SERVER_DRAIN_PERIOD" default:"0s"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" default:"10s"`
+	RequestTimeout    time.Duration `yaml:"request_timeout" env:"SERVER_REQUEST_TIMEOUT" default:"30s"`
+	MaxBodyBytes      int64         `yaml:"max_body_bytes" env:"SERVER_MAX_BODY_BYTES" default:"10485760"`
+	TrustedProxies    []string      `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+	GRPCPort          int           `yaml:"grpc_port" env:"GRPC_PORT" default:"9090"`
 }
 
 type DatabaseConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
+	Host     string `yaml:"host" env:"DB_HOST" default:"localhost"`
+	Port     int    `yaml:"port" env:"DB_PORT" default:"5432"`
+	User     string `yaml:"user" env:"DB_USER" default:"user"`
+	Password string `yaml:"password" env:"DB_PASSWORD" default:"password" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME" default:"db"`
+	SSLMode  string `yaml:"ssl_mode" env:"DB_SSLMODE" default:"disable"`
 }
 
 type BusinessConfig struct {
-	MaxProductsPerPage int
-	DefaultCurrency    string
+	MaxProductsPerPage  int           `yaml:"max_products_per_page" env:"MAX_PRODUCTS_PER_PAGE" default:"50"`
+	MaxBatchGetProducts int           `yaml:"max_batch_get_products" env:"MAX_BATCH_GET_PRODUCTS" default:"100"`
+	MaxBatchItems       int           `yaml:"max_batch_items" env:"MAX_BATCH_ITEMS" default:"1000"`
+	BatchChunkSize      int           `yaml:"batch_chunk_size" env:"BATCH_CHUNK_SIZE" default:"100"`
+	ExportTimeout       time.Duration `yaml:"export_timeout" env:"EXPORT_TIMEOUT" default:"10m"`
+	DefaultCurrency     string        `yaml:"default_currency" env:"DEFAULT_CURRENCY" default:"KRW"`
+}
+
+type StockConfig struct {
+	DefaultReservationTTL time.Duration `yaml:"default_reservation_ttl" env:"STOCK_RESERVATION_DEFAULT_TTL" default:"15m"`
+	MaxReservationTTL     time.Duration `yaml:"max_reservation_ttl" env:"STOCK_RESERVATION_MAX_TTL" default:"24h"`
+	SweepInterval         time.Duration `yaml:"sweep_interval" env:"STOCK_RESERVATION_SWEEP_INTERVAL" default:"30s"`
+	SweepBatchSize        int           `yaml:"sweep_batch_size" env:"STOCK_RESERVATION_SWEEP_BATCH_SIZE" default:"100"`
+}
+
+type ImportConfig struct {
+	MaxBytes     int64         `yaml:"max_bytes" env:"IMPORT_MAX_BYTES" default:"10485760"`
+	MaxErrors    int           `yaml:"max_errors" env:"IMPORT_MAX_ERRORS" default:"1000"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"IMPORT_POLL_INTERVAL" default:"2s"`
+	StaleAfter   time.Duration `yaml:"stale_after" env:"IMPORT_STALE_AFTER" default:"5m"`
+	MaxAttempts  int           `yaml:"max_attempts" env:"IMPORT_MAX_ATTEMPTS" default:"3"`
+}
+
+type OutboxConfig struct {
+	Publisher       string        `yaml:"publisher" env:"OUTBOX_PUBLISHER" default:"memory"`
+	PollInterval    time.Duration `yaml:"poll_interval" env:"OUTBOX_POLL_INTERVAL" default:"1s"`
+	BatchSize       int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" default:"100"`
+	RetryBackoff    time.Duration `yaml:"retry_backoff" env:"OUTBOX_RETRY_BACKOFF" default:"1s"`
+	MaxRetryBackoff time.Duration `yaml:"max_retry_backoff" env:"OUTBOX_MAX_RETRY_BACKOFF" default:"5m"`
+	Retention       time.Duration `yaml:"retention" env:"OUTBOX_RETENTION" default:"24h"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"OUTBOX_CLEANUP_INTERVAL" default:"1h"`
+	Topic           string        `yaml:"topic" env:"OUTBOX_TOPIC" default:"business"`
+	NATSURL         string        `yaml:"nats_url" env:"NATS_URL" default:"nats://localhost:4222"`
+	NATSStream      string        `yaml:"nats_stream" env:"NATS_STREAM" default:"BUSINESS"`
+	KafkaRESTURL    string        `yaml:"kafka_rest_url" env:"KAFKA_REST_URL" default:"http://localhost:8088"`
+}
+
+type SecretsConfig struct {
+	Provider        string        `yaml:"provider" env:"SECRETS_PROVIDER" default:"env"`
+	Dir             string        `yaml:"dir" env:"SECRETS_DIR" default:"/run/secrets"`
+	RefreshInterval time.Duration `yaml:"refresh_interval" env:"SECRETS_REFRESH_INTERVAL" default:"1m"`
+}
+
+type TelemetryConfig struct {
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"OTLP_ENDPOINT"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env:"OTLP_INSECURE" default:"true"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1.0"`
 }
 
 type LogConfig struct {
-	Level string
-}
-
-// LoadConfig loads configuration from environment variables
-func LoadConfig() *Config {
-	return &Config{
-		Server: ServerConfig{
-			Port: config.GetEnvAsInt("PORT", 8080),
-			Host: config.GetEnv("HOST", "0.0.0.0"),
-		},
-		Database: DatabaseConfig{
-			Host:     config.GetEnv("DB_HOST", "localhost"),
-			Port:     config.GetEnvAsInt("DB_PORT", 5432),
-			User:     config.GetEnv("DB_USER", "user"),
-			Password: config.GetEnv("DB_PASSWORD", "password"),
-			Name:     config.GetEnv("DB_NAME", "db"),
-			SSLMode:  config.GetEnv("DB_SSLMODE", "disable"),
-		},
-		Business: BusinessConfig{
-			MaxProductsPerPage: config.GetEnvAsInt("MAX_PRODUCTS_PER_PAGE", 50),
-			DefaultCurrency:    config.GetEnv("DEFAULT_CURRENCY", "KRW"),
-		},
-		Log: LogConfig{
-			Level: config.GetEnv("LOG_LEVEL", "info"),
-		},
+	Level            string   `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Format           string   `yaml:"format" env:"LOG_FORMAT" default:"json"`
+	AccessSkipPaths  []string `yaml:"access_skip_paths" env:"ACCESS_LOG_SKIP_PATHS" default:"/health,/metrics"`
+	AccessSampleRate float64  `yaml:"access_sample_rate" env:"ACCESS_LOG_SAMPLE_RATE" default:"1"`
+}
+
+// AccessLog returns the access log settings for middleware.LoggingMiddleware
+func (c LogConfig) AccessLog() middleware.AccessLogConfig {
+	return middleware.AccessLogConfig{
+		SkipPaths:  c.AccessSkipPaths,
+		SampleRate: c.AccessSampleRate,
 	}
 }
 
+// AppConfig returns the HTTP server and shutdown settings for pkg/app
+func (c ServerConfig) AppConfig() app.Config {
+	return app.Config{
+		Addr:              net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		ReadTimeout:       c.ReadTimeout,
+		WriteTimeout:      c.WriteTimeout,
+		IdleTimeout:       c.IdleTimeout,
+		DrainPeriod:       c.DrainPeriod,
+		ShutdownTimeout:   c.ShutdownTimeout,
+	}
+}
+
+// GRPCAddr returns the address of the gRPC server that runs alongside the HTTP server
+func (c ServerConfig) GRPCAddr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.GRPCPort))
+}
+
+// LoadConfig loads configuration from defaults, an optional YAML file, environment variables and flags
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := config.Load(cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// validate rejects intervals the background workers cannot tick at
+func (c *Config) validate() error {
+	for _, interval := range []struct {
+		env   string
+		value time.Duration
+	}{
+		{"STOCK_RESERVATION_SWEEP_INTERVAL", c.Stock.SweepInterval},
+		{"IMPORT_POLL_INTERVAL", c.Import.PollInterval},
+		{"OUTBOX_POLL_INTERVAL", c.Outbox.PollInterval},
+		{"OUTBOX_CLEANUP_INTERVAL", c.Outbox.CleanupInterval},
+	} {
+		if interval.value <= 0 {
+			return fmt.Errorf("config: %s must be positive, got %s", interval.env, interval.value)
+		}
+	}
+	return nil
+}
+
+// Redacted returns the effective configuration with secrets masked, for logging at startup
+func (c *Config) Redacted() map[string]interface{} {
+	return config.Redacted(c)
+}
+
 // GetDatabaseURL returns formatted PostgreSQL connection string
 func (dCfg *DatabaseConfig) GetDatabaseURL() string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
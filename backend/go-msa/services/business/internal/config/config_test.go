@@ -0,0 +1,29 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigValidateIntervals(t *testing.T) {
+	valid := func() *Config {
+		cfg := &Config{}
+		cfg.Stock.SweepInterval = 30 * time.Second
+		cfg.Import.PollInterval = 2 * time.Second
+		cfg.Outbox.PollInterval = time.Second
+		cfg.Outbox.CleanupInterval = time.Hour
+		return cfg
+	}
+	if err := valid().validate(); err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+
+	for _, value := range []time.Duration{0, -time.Second} {
+		cfg := valid()
+		cfg.Stock.SweepInterval = value
+		if err := cfg.validate(); err == nil || !strings.Contains(err.Error(), "STOCK_RESERVATION_SWEEP_INTERVAL") {
+			t.Errorf("SweepInterval %v: error = %v", value, err)
+		}
+	}
+}
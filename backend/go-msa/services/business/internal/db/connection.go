@@ -5,9 +5,15 @@ import (
 	"fmt"
 
 	"business-service/internal/config"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	pkgconfig "pkg/config"
+	"pkg/telemetry"
 )
 
+// SchemaVersion is the latest flyway migration this build depends on
+const SchemaVersion = 6
+
 // Connection wraps the database connection and queries
 type Connection struct {
 	Pool    *pgxpool.Pool
@@ -15,7 +21,9 @@ type Connection struct {
 }
 
 // NewConnection creates a new PostgreSQL connection using pgx
-func NewConnection(cfg config.DatabaseConfig) (*Connection, error) {
+// The password is read from the secret for every new connection, so a rotated
+// password applies to the pool without a restart.
+func NewConnection(cfg config.DatabaseConfig, password *pkgconfig.Secret) (*Connection, error) {
 	// Build connection string
 	connStr := fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
@@ -27,8 +35,20 @@ func NewConnection(cfg config.DatabaseConfig) (*Connection, error) {
 		cfg.SSLMode,
 	)
 
+	poolConfig, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse connection string: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = telemetry.NewQueryTracer()
+	if password != nil {
+		poolConfig.BeforeConnect = func(_ context.Context, connConfig *pgx.ConnConfig) error {
+			connConfig.Password = password.Value()
+			return nil
+		}
+	}
+
 	// Create connection pool
-	pool, err := pgxpool.New(context.Background(), connStr)
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -54,3 +74,30 @@ func (c *Connection) Close() {
 		c.Pool.Close()
 	}
 }
+
+// ExecTx runs fn inside a single transaction, committing on success and rolling back on error
+func (c *Connection) ExecTx(ctx context.Context, fn func(q *Queries) error) error {
+	return c.ExecRawTx(ctx, func(tx pgx.Tx) error {
+		return fn(c.Queries.WithTx(tx))
+	})
+}
+
+// ExecRawTx is ExecTx for work sqlc cannot express, such as CopyFrom and savepoints.
+// Bind queries to the transaction with Queries.WithTx.
+func (c *Connection) ExecRawTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := c.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
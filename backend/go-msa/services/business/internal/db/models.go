@@ -11,6 +11,30 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type InventoryMovement struct {
+	ID          uuid.UUID   `db:"id" json:"id"`
+	ProductID   uuid.UUID   `db:"product_id" json:"product_id"`
+	Delta       int32       `db:"delta" json:"delta"`
+	Reason      string      `db:"reason" json:"reason"`
+	Actor       string      `db:"actor" json:"actor"`
+	ReferenceID pgtype.Text `db:"reference_id" json:"reference_id"`
+	CreatedAt   time.Time   `db:"created_at" json:"created_at"`
+}
+
+type Outbox struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	Seq           int64              `db:"seq" json:"seq"`
+	AggregateType string             `db:"aggregate_type" json:"aggregate_type"`
+	AggregateID   uuid.UUID          `db:"aggregate_id" json:"aggregate_id"`
+	EventType     string             `db:"event_type" json:"event_type"`
+	Payload       []byte             `db:"payload" json:"payload"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	LastError     pgtype.Text        `db:"last_error" json:"last_error"`
+	CreatedAt     time.Time          `db:"created_at" json:"created_at"`
+	PublishedAt   pgtype.Timestamptz `db:"published_at" json:"published_at"`
+	NextAttemptAt time.Time          `db:"next_attempt_at" json:"next_attempt_at"`
+}
+
 type Product struct {
 	ID          uuid.UUID      `db:"id" json:"id"`
 	Name        string         `db:"name" json:"name"`
@@ -20,3 +44,41 @@ type Product struct {
 	CreatedAt   time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time      `db:"updated_at" json:"updated_at"`
 }
+
+type ProductImportJob struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	Status        string             `db:"status" json:"status"`
+	Format        string             `db:"format" json:"format"`
+	Actor         string             `db:"actor" json:"actor"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	ProcessedRows int32              `db:"processed_rows" json:"processed_rows"`
+	CreatedRows   int32              `db:"created_rows" json:"created_rows"`
+	UpdatedRows   int32              `db:"updated_rows" json:"updated_rows"`
+	FailedRows    int32              `db:"failed_rows" json:"failed_rows"`
+	Errors        []byte             `db:"errors" json:"errors"`
+	LastError     pgtype.Text        `db:"last_error" json:"last_error"`
+	CreatedAt     time.Time          `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time          `db:"updated_at" json:"updated_at"`
+	StartedAt     pgtype.Timestamptz `db:"started_at" json:"started_at"`
+	FinishedAt    pgtype.Timestamptz `db:"finished_at" json:"finished_at"`
+}
+
+type ProductImportPayload struct {
+	JobID uuid.UUID `db:"job_id" json:"job_id"`
+	Data  []byte    `db:"data" json:"data"`
+}
+
+type ProductSku struct {
+	ExternalSku string    `db:"external_sku" json:"external_sku"`
+	ProductID   uuid.UUID `db:"product_id" json:"product_id"`
+}
+
+type StockReservation struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	ProductID uuid.UUID `db:"product_id" json:"product_id"`
+	Quantity  int32     `db:"quantity" json:"quantity"`
+	Status    string    `db:"status" json:"status"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// sqlc collects :many results into a slice, so the streaming export query is written by hand
+
+const StreamProducts = `
+SELECT p.id, p.name, p.description, p.price, p.stock, p.created_at, p.updated_at, s.external_sku
+FROM products p
+LEFT JOIN product_skus s ON s.product_id = p.id
+ORDER BY p.created_at, p.id
+`
+
+// ProductExportRow is a product together with its external SKU, if it has one
+type ProductExportRow struct {
+	Product
+	ExternalSku pgtype.Text `db:"external_sku" json:"external_sku"`
+}
+
+// StreamProducts calls fn for every product in creation order. Rows are read from the connection as fn
+// consumes them, so the table is never held in memory. row is reused between calls; an error from fn stops the stream.
+func (q *Queries) StreamProducts(ctx context.Context, fn func(row *ProductExportRow) error) error {
+	rows, err := q.db.Query(ctx, StreamProducts)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var i ProductExportRow
+	for rows.Next() {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Stock,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExternalSku,
+		); err != nil {
+			return err
+		}
+		if err := fn(&i); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -12,6 +12,33 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const AdjustProductStock = `-- name: AdjustProductStock :one
+UPDATE products
+SET stock = stock + $1::int, updated_at = NOW()
+WHERE id = $2 AND stock + $1::int >= 0
+RETURNING id, name, description, price, stock, created_at, updated_at
+`
+
+type AdjustProductStockParams struct {
+	Delta int32     `db:"delta" json:"delta"`
+	ID    uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) AdjustProductStock(ctx context.Context, arg AdjustProductStockParams) (*Product, error) {
+	row := q.db.QueryRow(ctx, AdjustProductStock, arg.Delta, arg.ID)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const CreateProduct = `-- name: CreateProduct :one
 INSERT INTO products (name, description, price, stock)
 VALUES ($1, $2, $3, $4)
@@ -45,6 +72,41 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (*
 	return &i, err
 }
 
+const CreateProductWithID = `-- name: CreateProductWithID :one
+INSERT INTO products (id, name, description, price, stock)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, description, price, stock, created_at, updated_at
+`
+
+type CreateProductWithIDParams struct {
+	ID          uuid.UUID      `db:"id" json:"id"`
+	Name        string         `db:"name" json:"name"`
+	Description pgtype.Text    `db:"description" json:"description"`
+	Price       pgtype.Numeric `db:"price" json:"price"`
+	Stock       int32          `db:"stock" json:"stock"`
+}
+
+func (q *Queries) CreateProductWithID(ctx context.Context, arg CreateProductWithIDParams) (*Product, error) {
+	row := q.db.QueryRow(ctx, CreateProductWithID,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.Price,
+		arg.Stock,
+	)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const DeleteProduct = `-- name: DeleteProduct :exec
 DELETE FROM products
 WHERE id = $1
@@ -76,6 +138,62 @@ func (q *Queries) GetProduct(ctx context.Context, id uuid.UUID) (*Product, error
 	return &i, err
 }
 
+const GetProductForUpdate = `-- name: GetProductForUpdate :one
+SELECT id, name, description, price, stock, created_at, updated_at
+FROM products
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetProductForUpdate(ctx context.Context, id uuid.UUID) (*Product, error) {
+	row := q.db.QueryRow(ctx, GetProductForUpdate, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetProductsByIDs = `-- name: GetProductsByIDs :many
+SELECT id, name, description, price, stock, created_at, updated_at
+FROM products
+WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) GetProductsByIDs(ctx context.Context, ids []uuid.UUID) ([]*Product, error) {
+	rows, err := q.db.Query(ctx, GetProductsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Stock,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetProductsByPriceRange = `-- name: GetProductsByPriceRange :many
 SELECT id, name, description, price, stock, created_at, updated_at
 FROM products
@@ -11,11 +11,43 @@ import (
 )
 
 type Querier interface {
+	AdjustProductStock(ctx context.Context, arg AdjustProductStockParams) (*Product, error)
+	ClaimProductImportJob(ctx context.Context, staleSeconds int32) (*ProductImportJob, error)
+	ConfirmStockReservation(ctx context.Context, id uuid.UUID) (*StockReservation, error)
+	CreateInventoryMovement(ctx context.Context, arg CreateInventoryMovementParams) (*InventoryMovement, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (*Outbox, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (*Product, error)
+	CreateProductImportJob(ctx context.Context, arg CreateProductImportJobParams) (*ProductImportJob, error)
+	CreateProductImportPayload(ctx context.Context, arg CreateProductImportPayloadParams) error
+	CreateProductSKU(ctx context.Context, arg CreateProductSKUParams) error
+	CreateProductWithID(ctx context.Context, arg CreateProductWithIDParams) (*Product, error)
+	CreateStockReservation(ctx context.Context, arg CreateStockReservationParams) (*StockReservation, error)
 	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	DeleteProductImportPayload(ctx context.Context, jobID uuid.UUID) error
+	DeletePublishedOutboxEvents(ctx context.Context, retentionSeconds int32) (int64, error)
+	ExpireStockReservations(ctx context.Context, limit int32) ([]*StockReservation, error)
+	// Fenced like RecordProductImportProgress
+	FinishProductImportJob(ctx context.Context, arg FinishProductImportJobParams) (int64, error)
+	GetLedgerStock(ctx context.Context, productID uuid.UUID) (int32, error)
 	GetProduct(ctx context.Context, id uuid.UUID) (*Product, error)
+	GetProductForUpdate(ctx context.Context, id uuid.UUID) (*Product, error)
+	GetProductIDBySKUForUpdate(ctx context.Context, externalSku string) (uuid.UUID, error)
+	GetProductImportJob(ctx context.Context, id uuid.UUID) (*ProductImportJob, error)
+	GetProductImportPayload(ctx context.Context, jobID uuid.UUID) ([]byte, error)
+	GetProductsByIDs(ctx context.Context, ids []uuid.UUID) ([]*Product, error)
 	GetProductsByPriceRange(ctx context.Context, arg GetProductsByPriceRangeParams) ([]*Product, error)
+	GetStockReservation(ctx context.Context, id uuid.UUID) (*StockReservation, error)
+	ListInventoryMovements(ctx context.Context, arg ListInventoryMovementsParams) ([]*InventoryMovement, error)
+	// An event is held back while it, or an earlier event of its aggregate, waits out a retry backoff
+	ListPendingOutboxEvents(ctx context.Context, limit int32) ([]*Outbox, error)
 	ListProducts(ctx context.Context) ([]*Product, error)
+	ListStockDiscrepancies(ctx context.Context, arg ListStockDiscrepanciesParams) ([]*ListStockDiscrepanciesRow, error)
+	MarkOutboxEventPublished(ctx context.Context, id uuid.UUID) error
+	RecordOutboxEventFailure(ctx context.Context, arg RecordOutboxEventFailureParams) error
+	// Only the worker holding the job's current attempt may record progress; a job taken over as stale is fenced off
+	RecordProductImportProgress(ctx context.Context, arg RecordProductImportProgressParams) (int64, error)
+	ReleaseStockReservation(ctx context.Context, id uuid.UUID) (*StockReservation, error)
+	TryOutboxRelayLock(ctx context.Context, lockKey int64) (bool, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (*Product, error)
 	UpdateProductStock(ctx context.Context, arg UpdateProductStockParams) (*Product, error)
 }
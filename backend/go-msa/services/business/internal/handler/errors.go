@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"business-service/internal/service"
+	"pkg/common_handler"
+	apperrors "pkg/errors"
+	"pkg/logger"
+)
+
+// NewErrorHandler maps business-service domain errors to HTTP responses
+func NewErrorHandler(log logger.Logger) *common_handler.ErrorHandler {
+	return common_handler.NewErrorHandler(log).
+		Map(service.ErrInvalidProductID, http.StatusBadRequest, "INVALID_PRODUCT_ID").
+		Map(service.ErrInvalidQuantity, http.StatusBadRequest, "INVALID_QUANTITY").
+		Map(service.ErrInvalidReservationTTL, http.StatusBadRequest, "INVALID_RESERVATION_TTL").
+		Map(service.ErrInvalidMovementReason, http.StatusBadRequest, "INVALID_MOVEMENT_REASON").
+		Map(service.ErrInvalidImportJobID, http.StatusBadRequest, "INVALID_IMPORT_JOB_ID").
+		Map(service.ErrUnsupportedFormat, http.StatusBadRequest, "UNSUPPORTED_FORMAT").
+		Map(service.ErrProductNotFound, http.StatusNotFound, "PRODUCT_NOT_FOUND").
+		Map(service.ErrReservationNotFound, http.StatusNotFound, "RESERVATION_NOT_FOUND").
+		Map(service.ErrImportJobNotFound, http.StatusNotFound, "IMPORT_JOB_NOT_FOUND").
+		Map(service.ErrInsufficientStock, http.StatusConflict, "INSUFFICIENT_STOCK").
+		Map(service.ErrReservationNotPending, http.StatusConflict, "RESERVATION_NOT_PENDING")
+}
+
+// requireUserID returns the user the gateway authenticated
+func requireUserID(r *http.Request) (string, error) {
+	userID := r.Header.Get("X-Authenticated-User-ID")
+	if userID == "" {
+		return "", apperrors.NewUnauthorizedError("Unauthorized")
+	}
+	return userID, nil
+}
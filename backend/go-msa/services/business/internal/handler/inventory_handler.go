@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"business-service/internal/db"
+	"business-service/internal/service"
+	"github.com/google/uuid"
+	apperrors "pkg/errors"
+)
+
+type InventoryHandler struct {
+	inventoryService *service.InventoryService
+	maxPageSize      int
+}
+
+func NewInventoryHandler(inventoryService *service.InventoryService, maxPageSize int) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+		maxPageSize:      maxPageSize,
+	}
+}
+
+type MovementResponse struct {
+	ID          string `json:"id"`
+	ProductID   string `json:"product_id"`
+	Delta       int32  `json:"delta"`
+	Reason      string `json:"reason"`
+	Actor       string `json:"actor"`
+	ReferenceID string `json:"reference_id,omitempty"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type StockConsistencyResponse struct {
+	ProductID   string `json:"product_id"`
+	Stock       int32  `json:"stock"`
+	LedgerStock int32  `json:"ledger_stock"`
+	Consistent  bool   `json:"consistent"`
+}
+
+// StockDiscrepanciesResponse is a page of discrepancies; NextCursor is empty on the last page
+type StockDiscrepanciesResponse struct {
+	Discrepancies []*StockConsistencyResponse `json:"discrepancies"`
+	NextCursor    string                      `json:"next_cursor,omitempty"`
+}
+
+// convertMovementToResponse converts db.InventoryMovement to MovementResponse
+func convertMovementToResponse(movement *db.InventoryMovement) *MovementResponse {
+	referenceID := ""
+	if movement.ReferenceID.Valid {
+		referenceID = movement.ReferenceID.String
+	}
+
+	return &MovementResponse{
+		ID:          movement.ID.String(),
+		ProductID:   movement.ProductID.String(),
+		Delta:       movement.Delta,
+		Reason:      movement.Reason,
+		Actor:       movement.Actor,
+		ReferenceID: referenceID,
+		CreatedAt:   movement.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// convertConsistencyToResponse converts service.StockConsistency to StockConsistencyResponse
+func convertConsistencyToResponse(consistency *service.StockConsistency) *StockConsistencyResponse {
+	return &StockConsistencyResponse{
+		ProductID:   consistency.ProductID.String(),
+		Stock:       consistency.Stock,
+		LedgerStock: consistency.LedgerStock,
+		Consistent:  consistency.Consistent(),
+	}
+}
+
+// ListMovements handles GET /products/{id}/stock/movements
+func (h *InventoryHandler) ListMovements(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid product ID format")
+	}
+
+	limit, err := parseQueryInt(r, "limit", h.maxPageSize)
+	if err != nil || limit <= 0 || limit > h.maxPageSize {
+		return apperrors.NewBadRequestError(fmt.Sprintf("limit must be between 1 and %d", h.maxPageSize))
+	}
+
+	offset, err := parseQueryInt(r, "offset", 0)
+	if err != nil || offset < 0 {
+		return apperrors.NewBadRequestError("offset must be non-negative")
+	}
+
+	ctx := r.Context()
+	movements, err := h.inventoryService.ListMovements(ctx, id, int32(limit), int32(offset))
+	if err != nil {
+		return err
+	}
+
+	responses := make([]*MovementResponse, len(movements))
+	for i, movement := range movements {
+		responses[i] = convertMovementToResponse(movement)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(responses)
+}
+
+// CheckConsistency handles GET /products/{id}/stock/consistency
+func (h *InventoryHandler) CheckConsistency(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid product ID format")
+	}
+
+	ctx := r.Context()
+	consistency, err := h.inventoryService.CheckConsistency(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	response := convertConsistencyToResponse(consistency)
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// ListDiscrepancies handles GET /stock/discrepancies
+func (h *InventoryHandler) ListDiscrepancies(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
+	}
+
+	limit, err := parseQueryInt(r, "limit", h.maxPageSize)
+	if err != nil || limit <= 0 || limit > h.maxPageSize {
+		return apperrors.NewBadRequestError(fmt.Sprintf("limit must be between 1 and %d", h.maxPageSize))
+	}
+
+	var after service.DiscrepancyCursor
+	if value := r.URL.Query().Get("cursor"); value != "" {
+		if after, err = decodeDiscrepancyCursor(value); err != nil {
+			return apperrors.NewBadRequestError("Invalid cursor")
+		}
+	}
+
+	ctx := r.Context()
+	discrepancies, next, err := h.inventoryService.ListDiscrepancies(ctx, after, int32(limit))
+	if err != nil {
+		return err
+	}
+
+	response := StockDiscrepanciesResponse{
+		Discrepancies: make([]*StockConsistencyResponse, len(discrepancies)),
+	}
+	for i, discrepancy := range discrepancies {
+		response.Discrepancies[i] = convertConsistencyToResponse(discrepancy)
+	}
+	if next != nil {
+		response.NextCursor = encodeDiscrepancyCursor(*next)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// encodeDiscrepancyCursor turns a cursor into the opaque token clients pass back as ?cursor=
+func encodeDiscrepancyCursor(cursor service.DiscrepancyCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + cursor.ProductID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeDiscrepancyCursor reverses encodeDiscrepancyCursor
+func decodeDiscrepancyCursor(token string) (service.DiscrepancyCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return service.DiscrepancyCursor{}, err
+	}
+	createdAt, id, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return service.DiscrepancyCursor{}, fmt.Errorf("malformed cursor")
+	}
+
+	var cursor service.DiscrepancyCursor
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return service.DiscrepancyCursor{}, err
+	}
+	if cursor.ProductID, err = uuid.Parse(id); err != nil {
+		return service.DiscrepancyCursor{}, err
+	}
+	return cursor, nil
+}
+
+// parseQueryInt reads an integer query parameter, falling back to defaultValue when absent
+func parseQueryInt(r *http.Request, key string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"business-service/internal/service"
+	"github.com/google/uuid"
+	"pkg/logger"
+)
+
+func TestDiscrepancyCursorRoundTrip(t *testing.T) {
+	cursor := service.DiscrepancyCursor{
+		CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC),
+		ProductID: uuid.New(),
+	}
+
+	got, err := decodeDiscrepancyCursor(encodeDiscrepancyCursor(cursor))
+	if err != nil || !got.CreatedAt.Equal(cursor.CreatedAt) || got.ProductID != cursor.ProductID {
+		t.Errorf("round trip = %+v, %v, want %+v", got, err, cursor)
+	}
+}
+
+func TestListDiscrepanciesRejectsBadPages(t *testing.T) {
+	errorHandler := NewErrorHandler(logger.NewWithWriters("business-service", "error", "json", io.Discard, io.Discard))
+	// The inventory service is nil, so reaching it would panic
+	h := NewInventoryHandler(nil, 50)
+
+	for _, query := range []string{"?limit=0", "?limit=51", "?cursor=not-a-cursor", "?cursor=YWJj"} {
+		r := httptest.NewRequest(http.MethodGet, "/v1/business/stock/discrepancies"+query, nil)
+		r.Header.Set("X-Authenticated-User-ID", "user-1")
+
+		err := h.ListDiscrepancies(httptest.NewRecorder(), r)
+
+		if appErr := errorHandler.Resolve(err); appErr.Code != "BAD_REQUEST" {
+			t.Errorf("%s: error = %v, want BAD_REQUEST", query, err)
+		}
+	}
+}
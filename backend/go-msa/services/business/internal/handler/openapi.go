@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+
+	"pkg/openapi"
+	"pkg/router"
+)
+
+// NewOpenAPISpec documents every business-service route registered on v1
+func NewOpenAPISpec(title string, v1 *router.VersionedRoutes) *openapi.Spec {
+	spec := openapi.New(title, v1.Version())
+	tags := []string{"products"}
+	stockTags := []string{"stock"}
+
+	spec.Add(v1.Pattern("GET products"), openapi.Endpoint{
+		Summary: "List products, optionally within a price range",
+		Tags:    tags,
+		Query: []openapi.Parameter{
+			openapi.QueryParam("min_price", "number", "Lower price bound; requires max_price", false),
+			openapi.QueryParam("max_price", "number", "Upper price bound; requires min_price", false),
+		},
+		Response: []ProductResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	})
+	spec.Add(v1.Pattern("POST products"), openapi.Endpoint{
+		Summary:  "Create a product",
+		Tags:     tags,
+		Request:  CreateProductRequest{},
+		Response: ProductResponse{},
+	})
+	spec.Add(v1.Pattern("POST products/batch"), openapi.Endpoint{
+		Summary:     "Create products in bulk",
+		Description: "Atomic mode creates every item or none and replies 422 if any item fails; best_effort commits the items that succeed.",
+		Tags:        tags,
+		Request:     BatchCreateProductsRequest{},
+		Response:    BatchResponse{},
+		Errors:      []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	})
+	spec.Add(v1.Pattern("PUT products/batch"), openapi.Endpoint{
+		Summary:     "Update products in bulk",
+		Description: "Atomic mode updates every item or none and replies 422 if any item fails; best_effort commits the items that succeed.",
+		Tags:        tags,
+		Request:     BatchUpdateProductsRequest{},
+		Response:    BatchResponse{},
+		Errors:      []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	})
+	spec.Add(v1.Pattern("DELETE products/batch"), openapi.Endpoint{
+		Summary:     "Delete products in bulk",
+		Description: "Atomic mode deletes every item or none and replies 422 if any item fails; best_effort commits the items that succeed.",
+		Tags:        tags,
+		Request:     BatchDeleteProductsRequest{},
+		Response:    BatchResponse{},
+		Errors:      []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	})
+	spec.Add(v1.Pattern("GET products/export"), openapi.Endpoint{
+		Summary:     "Export all products as a CSV or NDJSON file",
+		Description: "Rows are streamed in creation order. The file can be edited and imported back; id, created_at and updated_at are ignored on import.",
+		Tags:        tags,
+		Query: []openapi.Parameter{
+			openapi.QueryParam("format", "string", "csv (default) or ndjson", false),
+		},
+		ResponseMediaTypes: []string{"text/csv", "application/x-ndjson"},
+		Errors:             []int{http.StatusBadRequest},
+	})
+	spec.Add(v1.Pattern("POST products/imports"), openapi.Endpoint{
+		Summary:     "Queue a CSV or NDJSON file of products to upsert by external_sku",
+		Description: "Columns are external_sku, name, description, price and stock. Rows are validated and applied in the background; poll the returned job for progress and line-level errors.",
+		Tags:        tags,
+		Query: []openapi.Parameter{
+			openapi.QueryParam("format", "string", "csv or ndjson; defaults to the Content-Type", false),
+		},
+		RequestMediaTypes: []string{"text/csv", "application/x-ndjson"},
+		Response:          ImportJobResponse{},
+		Status:            http.StatusAccepted,
+		Errors:            []int{http.StatusRequestEntityTooLarge},
+	})
+	spec.Add(v1.Pattern("GET products/imports/{id}"), openapi.Endpoint{
+		Summary:  "Get the status of a product import job",
+		Tags:     tags,
+		Response: ImportJobResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	spec.Add(v1.Pattern("GET products/{id}"), openapi.Endpoint{
+		Summary:  "Get a product",
+		Tags:     tags,
+		Response: ProductResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	spec.Add(v1.Pattern("POST products/{id}/stock/adjust"), openapi.Endpoint{
+		Summary:  "Adjust stock by a signed delta and record the movement",
+		Tags:     stockTags,
+		Request:  AdjustStockRequest{},
+		Response: ProductResponse{},
+		Errors:   []int{http.StatusNotFound, http.StatusConflict},
+	})
+	spec.Add(v1.Pattern("POST products/{id}/stock/reservations"), openapi.Endpoint{
+		Summary:  "Reserve stock until confirmed, released or expired",
+		Tags:     stockTags,
+		Request:  ReserveStockRequest{},
+		Response: ReservationResponse{},
+		Status:   http.StatusCreated,
+		Errors:   []int{http.StatusNotFound, http.StatusConflict},
+	})
+	spec.Add(v1.Pattern("GET products/{id}/stock/movements"), openapi.Endpoint{
+		Summary: "List stock movements of a product, newest first",
+		Tags:    stockTags,
+		Query: []openapi.Parameter{
+			openapi.QueryParam("limit", "integer", "Page size", false),
+			openapi.QueryParam("offset", "integer", "Rows to skip", false),
+		},
+		Response: []MovementResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	})
+	spec.Add(v1.Pattern("GET products/{id}/stock/consistency"), openapi.Endpoint{
+		Summary:  "Compare a product's stock with its movement ledger",
+		Tags:     stockTags,
+		Response: StockConsistencyResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	spec.Add(v1.Pattern("GET stock/discrepancies"), openapi.Endpoint{
+		Summary: "List products whose stock disagrees with the ledger, oldest first",
+		Tags:    stockTags,
+		Query: []openapi.Parameter{
+			openapi.QueryParam("limit", "integer", "Page size", false),
+			openapi.QueryParam("cursor", "string", "next_cursor of the previous page", false),
+		},
+		Response: StockDiscrepanciesResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	})
+	spec.Add(v1.Pattern("GET reservations/{id}"), openapi.Endpoint{
+		Summary:  "Get a stock reservation",
+		Tags:     stockTags,
+		Response: ReservationResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	spec.Add(v1.Pattern("POST reservations/{id}/confirm"), openapi.Endpoint{
+		Summary:  "Confirm a pending reservation",
+		Tags:     stockTags,
+		Response: ReservationResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	})
+	spec.Add(v1.Pattern("POST reservations/{id}/release"), openapi.Endpoint{
+		Summary:  "Release a pending reservation and return its stock",
+		Tags:     stockTags,
+		Response: ReservationResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	})
+
+	return spec
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"business-service/internal/service"
+	"github.com/google/uuid"
+	"pkg/common_handler"
+	"pkg/logger"
+	"pkg/models"
+	"pkg/validation"
+)
+
+// ProductBatchHandler creates, updates and deletes many products per request.
+// Items are validated one by one so that a bad item is reported in place instead of failing the request.
+type ProductBatchHandler struct {
+	productService *service.ProductService
+	errorHandler   *common_handler.ErrorHandler
+	log            logger.Logger
+	maxItems       int
+	maxBodyBytes   int64
+}
+
+func NewProductBatchHandler(productService *service.ProductService, errorHandler *common_handler.ErrorHandler, log logger.Logger, maxItems int, maxBodyBytes int64) *ProductBatchHandler {
+	return &ProductBatchHandler{
+		productService: productService,
+		errorHandler:   errorHandler,
+		log:            log,
+		maxItems:       maxItems,
+		maxBodyBytes:   maxBodyBytes,
+	}
+}
+
+// BatchCreateProductsRequest creates products; mode defaults to atomic
+type BatchCreateProductsRequest struct {
+	Mode  string                 `json:"mode" validate:"oneof=atomic best_effort"`
+	Items []CreateProductRequest `json:"items" validate:"-"`
+}
+
+// BatchUpdateProductItem is one product to overwrite, identified by ID
+type BatchUpdateProductItem struct {
+	ID          string  `json:"id" validate:"required,uuid"`
+	Name        string  `json:"name" validate:"required,max=255"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price" validate:"min=0,max=99999999.99"`
+	Stock       int32   `json:"stock" validate:"min=0"`
+}
+
+// BatchUpdateProductsRequest overwrites products by ID; mode defaults to atomic
+type BatchUpdateProductsRequest struct {
+	Mode  string                   `json:"mode" validate:"oneof=atomic best_effort"`
+	Items []BatchUpdateProductItem `json:"items" validate:"-"`
+}
+
+// BatchDeleteProductsRequest deletes products by ID; mode defaults to atomic
+type BatchDeleteProductsRequest struct {
+	Mode string   `json:"mode" validate:"oneof=atomic best_effort"`
+	IDs  []string `json:"ids" validate:"-"`
+}
+
+// BatchResponse is the APIResponse envelope bulk endpoints reply with. Items carry ProductResponse data.
+type BatchResponse struct {
+	models.APIResponse
+	Data models.BatchResult `json:"data"`
+}
+
+func (h *ProductBatchHandler) CreateProducts(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	var req BatchCreateProductsRequest
+	if err := validation.DecodeJSONWithLimit(w, r, &req, h.maxBodyBytes); err != nil {
+		return err
+	}
+	if err := h.checkSize("items", len(req.Items)); err != nil {
+		return err
+	}
+
+	invalid := make([]error, len(req.Items))
+	for i, item := range req.Items {
+		invalid[i] = validation.Validate(item)
+	}
+
+	mode := batchMode(req.Mode)
+	return h.respond(w, r, mode, invalid, nil, func(valid []int) []service.BatchItemResult {
+		inputs := make([]service.ProductInput, len(valid))
+		for k, i := range valid {
+			item := req.Items[i]
+			inputs[k] = service.ProductInput{
+				Name:        item.Name,
+				Description: item.Description,
+				Price:       item.Price,
+				Stock:       item.Stock,
+			}
+		}
+		return h.productService.CreateProducts(r.Context(), userID, mode, inputs)
+	})
+}
+
+func (h *ProductBatchHandler) UpdateProducts(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	var req BatchUpdateProductsRequest
+	if err := validation.DecodeJSONWithLimit(w, r, &req, h.maxBodyBytes); err != nil {
+		return err
+	}
+	if err := h.checkSize("items", len(req.Items)); err != nil {
+		return err
+	}
+
+	ids := make([]string, len(req.Items))
+	invalid := make([]error, len(req.Items))
+	for i, item := range req.Items {
+		ids[i] = item.ID
+		invalid[i] = validation.Validate(item)
+	}
+
+	mode := batchMode(req.Mode)
+	return h.respond(w, r, mode, invalid, ids, func(valid []int) []service.BatchItemResult {
+		inputs := make([]service.ProductInput, len(valid))
+		for k, i := range valid {
+			item := req.Items[i]
+			inputs[k] = service.ProductInput{
+				ID:          uuid.MustParse(item.ID),
+				Name:        item.Name,
+				Description: item.Description,
+				Price:       item.Price,
+				Stock:       item.Stock,
+			}
+		}
+		return h.productService.UpdateProducts(r.Context(), userID, mode, inputs)
+	})
+}
+
+func (h *ProductBatchHandler) DeleteProducts(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
+	}
+
+	var req BatchDeleteProductsRequest
+	if err := validation.DecodeJSONWithLimit(w, r, &req, h.maxBodyBytes); err != nil {
+		return err
+	}
+	if err := h.checkSize("ids", len(req.IDs)); err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(req.IDs))
+	invalid := make([]error, len(req.IDs))
+	for i, raw := range req.IDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			invalid[i] = fmt.Errorf("%w: %v", service.ErrInvalidProductID, err)
+			continue
+		}
+		ids[i] = id
+	}
+
+	mode := batchMode(req.Mode)
+	return h.respond(w, r, mode, invalid, req.IDs, func(valid []int) []service.BatchItemResult {
+		validIDs := make([]uuid.UUID, len(valid))
+		for k, i := range valid {
+			validIDs[k] = ids[i]
+		}
+		return h.productService.DeleteProducts(r.Context(), mode, validIDs)
+	})
+}
+
+// checkSize bounds the number of items per request
+func (h *ProductBatchHandler) checkSize(field string, n int) error {
+	return validation.New().
+		Check(n > 0, field, "is required").
+		Check(n <= h.maxItems, field, fmt.Sprintf("must contain at most %d items", h.maxItems)).
+		Err()
+}
+
+// respond passes the items that passed validation to apply and writes every item's outcome in request order.
+// An atomic batch with an invalid item is not applied at all. ids labels items that have no product in their result.
+func (h *ProductBatchHandler) respond(w http.ResponseWriter, r *http.Request, mode service.BatchMode, invalid []error, ids []string, apply func(valid []int) []service.BatchItemResult) error {
+	results := make([]service.BatchItemResult, len(invalid))
+	valid := make([]int, 0, len(invalid))
+	for i, err := range invalid {
+		if err != nil {
+			results[i] = service.BatchItemResult{Status: service.BatchItemFailed, Err: err}
+			continue
+		}
+		valid = append(valid, i)
+	}
+
+	switch {
+	case mode == service.BatchAtomic && len(valid) < len(invalid):
+		for _, i := range valid {
+			results[i].Status = service.BatchItemSkipped
+		}
+	case len(valid) > 0:
+		for k, result := range apply(valid) {
+			results[valid[k]] = result
+		}
+	}
+
+	batch := models.BatchResult{
+		Mode:  string(mode),
+		Total: len(results),
+		Items: make([]models.BatchItem, len(results)),
+	}
+	for i, result := range results {
+		item := models.BatchItem{Index: i, Status: string(result.Status)}
+		if ids != nil {
+			item.ID = ids[i]
+		}
+		if result.Product != nil {
+			item.ID = result.Product.ID.String()
+			item.Data = convertProductToResponse(result.Product)
+		}
+
+		switch result.Status {
+		case service.BatchItemSucceeded:
+			batch.Succeeded++
+		case service.BatchItemFailed:
+			batch.Failed++
+			item.Error = h.itemError(r, i, result.Err)
+		}
+		batch.Items[i] = item
+	}
+
+	response := models.NewSuccessResponse(batch)
+	response.Success = batch.Failed == 0
+	response.RequestID = logger.RequestIDFromContext(r.Context())
+
+	status := http.StatusOK
+	if mode == service.BatchAtomic && batch.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+		response.Error = &models.APIError{
+			Code:    "BATCH_ROLLED_BACK",
+			Message: "No items were applied because at least one item failed",
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// itemError maps an item's error with the same rules as whole-request errors, logging server errors
+func (h *ProductBatchHandler) itemError(r *http.Request, index int, err error) *models.APIError {
+	appErr := h.errorHandler.Resolve(err)
+	if appErr.StatusCode >= http.StatusInternalServerError {
+		h.log.ErrorContext(r.Context(), "Batch item failed",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"index", index,
+			"status", appErr.StatusCode,
+			"error", err,
+		)
+	}
+
+	return &models.APIError{
+		Code:    appErr.Code,
+		Message: appErr.Message,
+		Fields:  appErr.Fields,
+	}
+}
+
+// batchMode defaults an omitted mode to atomic
+func batchMode(mode string) service.BatchMode {
+	if mode == "" {
+		return service.BatchAtomic
+	}
+	return service.BatchMode(mode)
+}
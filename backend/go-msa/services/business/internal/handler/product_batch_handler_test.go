@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"business-service/internal/db"
+	"business-service/internal/service"
+	"github.com/google/uuid"
+	"pkg/logger"
+	"pkg/models"
+)
+
+func newTestBatchHandler(maxItems int) *ProductBatchHandler {
+	log := logger.NewWithWriters("business-service", "error", "json", io.Discard, io.Discard)
+	return NewProductBatchHandler(nil, NewErrorHandler(log), log, maxItems, 1<<20)
+}
+
+type batchTestResponse struct {
+	Success bool               `json:"success"`
+	Data    models.BatchResult `json:"data"`
+	Error   *models.APIError   `json:"error"`
+}
+
+func decodeBatchResponse(t *testing.T, w *httptest.ResponseRecorder) batchTestResponse {
+	t.Helper()
+	var response batchTestResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func batchStatuses(result models.BatchResult) []string {
+	statuses := make([]string, len(result.Items))
+	for i, item := range result.Items {
+		statuses[i] = item.Status
+	}
+	return statuses
+}
+
+func TestBatchRespondBestEffortAppliesValidItems(t *testing.T) {
+	h := newTestBatchHandler(10)
+	product := &db.Product{ID: uuid.New(), Name: "shoe"}
+	invalid := []error{nil, service.ErrInvalidProductID, nil}
+
+	var applied []int
+	w := httptest.NewRecorder()
+	err := h.respond(w, httptest.NewRequest(http.MethodPost, "/", nil), service.BatchBestEffort, invalid, nil, func(valid []int) []service.BatchItemResult {
+		applied = valid
+		return []service.BatchItemResult{
+			{Status: service.BatchItemSucceeded, Product: product},
+			{Status: service.BatchItemFailed, Err: errors.New("boom")},
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(applied) != 2 || applied[0] != 0 || applied[1] != 2 {
+		t.Errorf("applied %v, want [0 2]", applied)
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	response := decodeBatchResponse(t, w)
+	if got := strings.Join(batchStatuses(response.Data), ","); got != "succeeded,failed,failed" {
+		t.Errorf("statuses = %s", got)
+	}
+	if response.Success || response.Data.Succeeded != 1 || response.Data.Failed != 2 {
+		t.Errorf("response = %+v", response)
+	}
+	if response.Data.Items[0].ID != product.ID.String() {
+		t.Errorf("item 0 id = %q, want %q", response.Data.Items[0].ID, product.ID)
+	}
+	if code := response.Data.Items[1].Error.Code; code != "INVALID_PRODUCT_ID" {
+		t.Errorf("item 1 error code = %q, want INVALID_PRODUCT_ID", code)
+	}
+	if code := response.Data.Items[2].Error.Code; code != "INTERNAL_ERROR" {
+		t.Errorf("item 2 error code = %q, want INTERNAL_ERROR", code)
+	}
+}
+
+func TestBatchDeleteAtomicWithInvalidItemAppliesNothing(t *testing.T) {
+	h := newTestBatchHandler(10)
+	body := `{"mode":"atomic","ids":["` + uuid.NewString() + `","not-a-uuid"]}`
+	r := httptest.NewRequest(http.MethodDelete, "/v1/business/products/batch", strings.NewReader(body))
+	r.Header.Set("X-Authenticated-User-ID", "user-1")
+	w := httptest.NewRecorder()
+
+	// The product service is nil, so reaching it would panic
+	if err := h.DeleteProducts(w, r); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	response := decodeBatchResponse(t, w)
+	if response.Error == nil || response.Error.Code != "BATCH_ROLLED_BACK" {
+		t.Errorf("error = %+v, want BATCH_ROLLED_BACK", response.Error)
+	}
+	if got := strings.Join(batchStatuses(response.Data), ","); got != "skipped,failed" {
+		t.Errorf("statuses = %s, want skipped,failed", got)
+	}
+}
+
+func TestBatchRejectsEmptyAndOversizedRequests(t *testing.T) {
+	h := newTestBatchHandler(2)
+	for _, body := range []string{`{"ids":[]}`, `{"ids":["a","b","c"]}`} {
+		r := httptest.NewRequest(http.MethodDelete, "/v1/business/products/batch", strings.NewReader(body))
+		r.Header.Set("X-Authenticated-User-ID", "user-1")
+
+		err := h.DeleteProducts(httptest.NewRecorder(), r)
+
+		if appErr := h.errorHandler.Resolve(err); appErr.Code != "VALIDATION_ERROR" {
+			t.Errorf("%s: error = %v, want VALIDATION_ERROR", body, err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"business-service/internal/db"
+	"business-service/internal/service"
+	"pkg/logger"
+)
+
+// exportFlushRows is how many rows are written between flushes, so clients see progress on large exports
+const exportFlushRows = 500
+
+// productExportColumns are the CSV header names; they match what imports accept, plus read-only columns
+var productExportColumns = []string{"id", "external_sku", "name", "description", "price", "stock", "created_at", "updated_at"}
+
+// ProductExportHandler streams the products table as CSV or NDJSON
+type ProductExportHandler struct {
+	productService *service.ProductService
+	log            logger.Logger
+}
+
+func NewProductExportHandler(productService *service.ProductService, log logger.Logger) *ProductExportHandler {
+	return &ProductExportHandler{
+		productService: productService,
+		log:            log,
+	}
+}
+
+// ProductExportResponse is one NDJSON export line
+type ProductExportResponse struct {
+	ProductResponse
+	ExternalSKU string `json:"external_sku,omitempty"`
+}
+
+func (h *ProductExportHandler) ExportProducts(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
+	}
+
+	formatName := r.URL.Query().Get("format")
+	if formatName == "" {
+		formatName = string(service.ImportFormatCSV)
+	}
+	format, err := service.ParseImportFormat(formatName)
+	if err != nil {
+		return err
+	}
+
+	// The route has its own timeout, EXPORT_TIMEOUT, so a full export can outlive the usual request and
+	// write timeouts; it still stops as soon as the client goes away.
+	ctx := r.Context()
+	controller := http.NewResponseController(w)
+
+	writer := newProductExportWriter(format, w)
+	rows := 0
+	err = h.productService.ExportProducts(ctx, func(row *db.ProductExportRow) error {
+		if rows == 0 {
+			// Headers wait for the first row so a failing query can still be answered with an error response
+			writeExportHeaders(w, format)
+			if err := writer.begin(); err != nil {
+				return err
+			}
+		}
+		rows++
+
+		if err := writer.write(row); err != nil {
+			return err
+		}
+		if rows%exportFlushRows == 0 {
+			if err := writer.flush(); err != nil {
+				return err
+			}
+			return controller.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if rows == 0 {
+			return err
+		}
+		// The status line is gone; aborting the connection keeps the client from mistaking a partial file for a complete one
+		h.log.WarnContext(r.Context(), "Product export aborted", "rows", rows, "error", err)
+		panic(http.ErrAbortHandler)
+	}
+
+	if rows == 0 {
+		writeExportHeaders(w, format)
+		_ = writer.begin()
+	}
+	_ = writer.flush()
+	return nil
+}
+
+// writeExportHeaders marks the response as a downloadable file of format
+func writeExportHeaders(w http.ResponseWriter, format service.ImportFormat) {
+	contentType := "text/csv; charset=utf-8"
+	if format == service.ImportFormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="products.`+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+// productExportWriter encodes export rows in one format
+type productExportWriter interface {
+	begin() error
+	write(row *db.ProductExportRow) error
+	flush() error
+}
+
+func newProductExportWriter(format service.ImportFormat, w io.Writer) productExportWriter {
+	if format == service.ImportFormatNDJSON {
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w)}
+	}
+	return &csvExportWriter{writer: csv.NewWriter(w)}
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func (c *csvExportWriter) begin() error {
+	return c.writer.Write(productExportColumns)
+}
+
+func (c *csvExportWriter) write(row *db.ProductExportRow) error {
+	product := convertProductToResponse(&row.Product)
+	c.record = append(c.record[:0],
+		product.ID,
+		row.ExternalSku.String,
+		product.Name,
+		product.Description,
+		strconv.FormatFloat(product.Price, 'f', 2, 64),
+		strconv.FormatInt(int64(product.Stock), 10),
+		product.CreatedAt,
+		product.UpdatedAt,
+	)
+	return c.writer.Write(c.record)
+}
+
+func (c *csvExportWriter) flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonExportWriter) begin() error {
+	return nil
+}
+
+func (n *ndjsonExportWriter) write(row *db.ProductExportRow) error {
+	return n.encoder.Encode(ProductExportResponse{
+		ProductResponse: *convertProductToResponse(&row.Product),
+		ExternalSKU:     row.ExternalSku.String,
+	})
+}
+
+func (n *ndjsonExportWriter) flush() error {
+	return nil
+}
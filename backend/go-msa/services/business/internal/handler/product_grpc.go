@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"business-service/internal/db"
+	"business-service/internal/service"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	apperrors "pkg/errors"
+	businessv1 "pkg/proto/business/v1"
+	"pkg/rpc"
+	"pkg/validation"
+)
+
+// ProductGRPCServer serves business.v1.ProductService for internal callers.
+// Errors are returned unchanged and mapped to statuses by rpc.ErrorInterceptor, with the same rules as HTTP.
+type ProductGRPCServer struct {
+	businessv1.UnimplementedProductServiceServer
+	productService *service.ProductService
+	stockService   *service.StockService
+	maxBatchSize   int
+}
+
+func NewProductGRPCServer(productService *service.ProductService, stockService *service.StockService, maxBatchSize int) *ProductGRPCServer {
+	return &ProductGRPCServer{
+		productService: productService,
+		stockService:   stockService,
+		maxBatchSize:   maxBatchSize,
+	}
+}
+
+// GetProduct handles business.v1.ProductService/GetProduct
+func (s *ProductGRPCServer) GetProduct(ctx context.Context, req *businessv1.GetProductRequest) (*businessv1.Product, error) {
+	product, err := s.productService.GetProductByStringID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return convertProductToProto(product), nil
+}
+
+// BatchGetProducts handles business.v1.ProductService/BatchGetProducts
+func (s *ProductGRPCServer) BatchGetProducts(ctx context.Context, req *businessv1.BatchGetProductsRequest) (*businessv1.BatchGetProductsResponse, error) {
+	v := validation.New().
+		Check(len(req.GetIds()) > 0, "ids", "is required").
+		Check(len(req.GetIds()) <= s.maxBatchSize, "ids", fmt.Sprintf("must contain at most %d items", s.maxBatchSize))
+
+	ids := make([]uuid.UUID, 0, len(req.GetIds()))
+	for i, raw := range req.GetIds() {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			v.AddError(fmt.Sprintf("ids[%d]", i), "must be a valid UUID")
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	products, err := s.productService.GetProducts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]bool, len(products))
+	response := &businessv1.BatchGetProductsResponse{
+		Products: make([]*businessv1.Product, 0, len(products)),
+	}
+	for _, product := range products {
+		found[product.ID] = true
+		response.Products = append(response.Products, convertProductToProto(product))
+	}
+	for i, id := range ids {
+		if !found[id] {
+			response.MissingIds = append(response.MissingIds, req.GetIds()[i])
+		}
+	}
+
+	return response, nil
+}
+
+// ReserveStock handles business.v1.ProductService/ReserveStock
+func (s *ProductGRPCServer) ReserveStock(ctx context.Context, req *businessv1.ReserveStockRequest) (*businessv1.Reservation, error) {
+	if err := validation.New().Check(req.GetTtlSeconds() >= 0, "ttl_seconds", "must be greater than or equal to 0").Err(); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetProductId())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", service.ErrInvalidProductID, err)
+	}
+
+	ttl := time.Duration(req.GetTtlSeconds()) * time.Second
+	reservation, err := s.stockService.ReserveStock(ctx, id, req.GetQuantity(), ttl, rpc.UserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return convertReservationToProto(reservation), nil
+}
+
+// GetReservation handles business.v1.ProductService/GetReservation
+func (s *ProductGRPCServer) GetReservation(ctx context.Context, req *businessv1.GetReservationRequest) (*businessv1.Reservation, error) {
+	return s.handleReservation(ctx, req.GetId(), func(ctx context.Context, id uuid.UUID, _ string) (*db.StockReservation, error) {
+		return s.stockService.GetReservation(ctx, id)
+	})
+}
+
+// ConfirmReservation handles business.v1.ProductService/ConfirmReservation
+func (s *ProductGRPCServer) ConfirmReservation(ctx context.Context, req *businessv1.ConfirmReservationRequest) (*businessv1.Reservation, error) {
+	return s.handleReservation(ctx, req.GetId(), func(ctx context.Context, id uuid.UUID, _ string) (*db.StockReservation, error) {
+		return s.stockService.ConfirmReservation(ctx, id)
+	})
+}
+
+// ReleaseReservation handles business.v1.ProductService/ReleaseReservation
+func (s *ProductGRPCServer) ReleaseReservation(ctx context.Context, req *businessv1.ReleaseReservationRequest) (*businessv1.Reservation, error) {
+	return s.handleReservation(ctx, req.GetId(), s.stockService.ReleaseReservation)
+}
+
+// handleReservation runs a single-reservation operation addressed by rawID
+func (s *ProductGRPCServer) handleReservation(
+	ctx context.Context,
+	rawID string,
+	op func(ctx context.Context, id uuid.UUID, actor string) (*db.StockReservation, error),
+) (*businessv1.Reservation, error) {
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid reservation ID format")
+	}
+
+	reservation, err := op(ctx, id, rpc.UserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return convertReservationToProto(reservation), nil
+}
+
+// convertProductToProto converts db.Product to its protobuf message
+func convertProductToProto(product *db.Product) *businessv1.Product {
+	response := convertProductToResponse(product)
+	return &businessv1.Product{
+		Id:          response.ID,
+		Name:        response.Name,
+		Description: response.Description,
+		Price:       response.Price,
+		Stock:       response.Stock,
+		CreatedAt:   timestamppb.New(product.CreatedAt),
+		UpdatedAt:   timestamppb.New(product.UpdatedAt),
+	}
+}
+
+// convertReservationToProto converts db.StockReservation to its protobuf message
+func convertReservationToProto(reservation *db.StockReservation) *businessv1.Reservation {
+	return &businessv1.Reservation{
+		Id:        reservation.ID.String(),
+		ProductId: reservation.ProductID.String(),
+		Quantity:  reservation.Quantity,
+		Status:    reservation.Status,
+		ExpiresAt: timestamppb.New(reservation.ExpiresAt),
+		CreatedAt: timestamppb.New(reservation.CreatedAt),
+		UpdatedAt: timestamppb.New(reservation.UpdatedAt),
+	}
+}
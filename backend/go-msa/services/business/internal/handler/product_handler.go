@@ -2,13 +2,14 @@ package handler
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"business-service/internal/db"
 	"business-service/internal/service"
+	apperrors "pkg/errors"
+	"pkg/validation"
 )
 
 type ProductHandler struct {
@@ -22,10 +23,10 @@ func NewProductHandler(productService *service.ProductService) *ProductHandler {
 }
 
 type CreateProductRequest struct {
-	Name        string  `json:"name"`
+	Name        string  `json:"name" validate:"required,max=255"`
 	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Stock       int32   `json:"stock"`
+	Price       float64 `json:"price" validate:"min=0,max=99999999.99"`
+	Stock       int32   `json:"stock" validate:"min=0"`
 }
 
 type ProductResponse struct {
@@ -66,57 +67,37 @@ func convertProductToResponse(product *db.Product) *ProductResponse {
 	}
 }
 
-func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
-	userID := r.Header.Get("X-Authenticated-User-ID")
-	if userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
+func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
 	}
 
 	var req CreateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	// Validation
-	if req.Name == "" {
-		http.Error(w, "Product name is required", http.StatusBadRequest)
-		return
-	}
-	if req.Price < 0 {
-		http.Error(w, "Price must be non-negative", http.StatusBadRequest)
-		return
-	}
-	if req.Stock < 0 {
-		http.Error(w, "Stock must be non-negative", http.StatusBadRequest)
-		return
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		return err
 	}
 
 	ctx := r.Context()
-	product, err := h.productService.CreateProduct(ctx, req.Name, req.Description, req.Price, req.Stock)
+	product, err := h.productService.CreateProduct(ctx, userID, req.Name, req.Description, req.Price, req.Stock)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create product: %v", err), http.StatusInternalServerError)
-		return
+		return err
 	}
 
 	response := convertProductToResponse(product)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (h *ProductHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
-	userID := r.Header.Get("X-Authenticated-User-ID")
-	if userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
+func (h *ProductHandler) GetProducts(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
 	}
 
 	ctx := r.Context()
 	products, err := h.productService.ListProducts(ctx)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to fetch products: %v", err), http.StatusInternalServerError)
-		return
+		return err
 	}
 
 	responses := make([]*ProductResponse, len(products))
@@ -125,164 +106,106 @@ func (h *ProductHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(responses)
+	return json.NewEncoder(w).Encode(responses)
 }
 
-func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
-	userID := r.Header.Get("X-Authenticated-User-ID")
-	if userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	// Extract ID from URL path (assuming /products/{id})
-	path := r.URL.Path
-	parts := strings.Split(path, "/")
-	if len(parts) < 3 {
-		http.Error(w, "Product ID is required", http.StatusBadRequest)
-		return
+func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
 	}
-	id := parts[len(parts)-1]
 
-	if id == "" {
-		http.Error(w, "Product ID is required", http.StatusBadRequest)
-		return
+	id, err := productIDFromPath(r)
+	if err != nil {
+		return err
 	}
 
 	ctx := r.Context()
 	product, err := h.productService.GetProductByStringID(ctx, id)
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid product ID") {
-			http.Error(w, "Invalid product ID format", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Product not found", http.StatusNotFound)
-		return
+		return err
 	}
 
 	response := convertProductToResponse(product)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
-	userID := r.Header.Get("X-Authenticated-User-ID")
-	if userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
+func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
 	}
 
-	// Extract ID from URL path
-	path := r.URL.Path
-	parts := strings.Split(path, "/")
-	if len(parts) < 3 {
-		http.Error(w, "Product ID is required", http.StatusBadRequest)
-		return
+	id, err := productIDFromPath(r)
+	if err != nil {
+		return err
 	}
-	id := parts[len(parts)-1]
 
 	var req CreateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	// Validation
-	if req.Name == "" {
-		http.Error(w, "Product name is required", http.StatusBadRequest)
-		return
-	}
-	if req.Price < 0 {
-		http.Error(w, "Price must be non-negative", http.StatusBadRequest)
-		return
-	}
-	if req.Stock < 0 {
-		http.Error(w, "Stock must be non-negative", http.StatusBadRequest)
-		return
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		return err
 	}
 
 	ctx := r.Context()
-	product, err := h.productService.UpdateProductByStringID(ctx, id, req.Name, req.Description, req.Price, req.Stock)
+	product, err := h.productService.UpdateProductByStringID(ctx, userID, id, req.Name, req.Description, req.Price, req.Stock)
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid product ID") {
-			http.Error(w, "Invalid product ID format", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, fmt.Sprintf("Failed to update product: %v", err), http.StatusInternalServerError)
-		return
+		return err
 	}
 
 	response := convertProductToResponse(product)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
-	userID := r.Header.Get("X-Authenticated-User-ID")
-	if userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
+func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
 	}
 
-	// Extract ID from URL path
-	path := r.URL.Path
-	parts := strings.Split(path, "/")
-	if len(parts) < 3 {
-		http.Error(w, "Product ID is required", http.StatusBadRequest)
-		return
+	id, err := productIDFromPath(r)
+	if err != nil {
+		return err
 	}
-	id := parts[len(parts)-1]
 
 	ctx := r.Context()
 	if err := h.productService.DeleteProductByStringID(ctx, id); err != nil {
-		if strings.Contains(err.Error(), "invalid product ID") {
-			http.Error(w, "Invalid product ID format", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, fmt.Sprintf("Failed to delete product: %v", err), http.StatusInternalServerError)
-		return
+		return err
 	}
 
 	w.WriteHeader(http.StatusNoContent)
+	return nil
 }
 
-func (h *ProductHandler) GetProductsByPriceRange(w http.ResponseWriter, r *http.Request) {
-	userID := r.Header.Get("X-Authenticated-User-ID")
-	if userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
+func (h *ProductHandler) GetProductsByPriceRange(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
 	}
 
 	minPriceStr := r.URL.Query().Get("min_price")
 	maxPriceStr := r.URL.Query().Get("max_price")
 
 	if minPriceStr == "" || maxPriceStr == "" {
-		http.Error(w, "min_price and max_price query parameters are required", http.StatusBadRequest)
-		return
+		return apperrors.NewBadRequestError("min_price and max_price query parameters are required")
 	}
 
 	minPrice, err := strconv.ParseFloat(minPriceStr, 64)
 	if err != nil {
-		http.Error(w, "Invalid min_price format", http.StatusBadRequest)
-		return
+		return apperrors.NewBadRequestError("Invalid min_price format")
 	}
 
 	maxPrice, err := strconv.ParseFloat(maxPriceStr, 64)
 	if err != nil {
-		http.Error(w, "Invalid max_price format", http.StatusBadRequest)
-		return
+		return apperrors.NewBadRequestError("Invalid max_price format")
 	}
 
 	if minPrice < 0 || maxPrice < 0 || minPrice > maxPrice {
-		http.Error(w, "Invalid price range", http.StatusBadRequest)
-		return
+		return apperrors.NewBadRequestError("Invalid price range")
 	}
 
 	ctx := r.Context()
 	products, err := h.productService.GetProductsByPriceRange(ctx, minPrice, maxPrice)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to fetch products: %v", err), http.StatusInternalServerError)
-		return
+		return err
 	}
 
 	responses := make([]*ProductResponse, len(products))
@@ -291,5 +214,18 @@ func (h *ProductHandler) GetProductsByPriceRange(w http.ResponseWriter, r *http.
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(responses)
+	return json.NewEncoder(w).Encode(responses)
+}
+
+// productIDFromPath reads the product ID from the {id} path value, falling back to the last path segment
+func productIDFromPath(r *http.Request) (string, error) {
+	id := r.PathValue("id")
+	if id == "" {
+		parts := strings.Split(r.URL.Path, "/")
+		id = parts[len(parts)-1]
+	}
+	if id == "" {
+		return "", apperrors.NewBadRequestError("Product ID is required")
+	}
+	return id, nil
 }
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"business-service/internal/db"
+	"business-service/internal/service"
+	apperrors "pkg/errors"
+)
+
+// ProductImportHandler accepts product import files and reports on their jobs
+type ProductImportHandler struct {
+	importService *service.ProductImportService
+	maxBytes      int64
+}
+
+func NewProductImportHandler(importService *service.ProductImportService, maxBytes int64) *ProductImportHandler {
+	return &ProductImportHandler{
+		importService: importService,
+		maxBytes:      maxBytes,
+	}
+}
+
+type ImportJobResponse struct {
+	ID            string                   `json:"id"`
+	Status        string                   `json:"status"`
+	Format        string                   `json:"format"`
+	ProcessedRows int32                    `json:"processed_rows"`
+	CreatedRows   int32                    `json:"created_rows"`
+	UpdatedRows   int32                    `json:"updated_rows"`
+	FailedRows    int32                    `json:"failed_rows"`
+	Errors        []service.ImportRowError `json:"errors"`
+	// ErrorsTruncated is set when more rows failed than are described in Errors
+	ErrorsTruncated bool   `json:"errors_truncated,omitempty"`
+	Error           string `json:"error,omitempty"`
+	CreatedAt       string `json:"created_at"`
+	StartedAt       string `json:"started_at,omitempty"`
+	FinishedAt      string `json:"finished_at,omitempty"`
+}
+
+// convertImportJobToResponse converts db.ProductImportJob to ImportJobResponse
+func convertImportJobToResponse(job *db.ProductImportJob) (*ImportJobResponse, error) {
+	rowErrors := []service.ImportRowError{}
+	if err := json.Unmarshal(job.Errors, &rowErrors); err != nil {
+		return nil, fmt.Errorf("failed to decode import errors: %w", err)
+	}
+
+	lines := make(map[int]bool, len(rowErrors))
+	for _, rowErr := range rowErrors {
+		lines[rowErr.Line] = true
+	}
+
+	response := &ImportJobResponse{
+		ID:              job.ID.String(),
+		Status:          job.Status,
+		Format:          job.Format,
+		ProcessedRows:   job.ProcessedRows,
+		CreatedRows:     job.CreatedRows,
+		UpdatedRows:     job.UpdatedRows,
+		FailedRows:      job.FailedRows,
+		Errors:          rowErrors,
+		ErrorsTruncated: len(lines) < int(job.FailedRows),
+		Error:           job.LastError.String,
+		CreatedAt:       job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.StartedAt.Valid {
+		response.StartedAt = job.StartedAt.Time.Format(time.RFC3339)
+	}
+	if job.FinishedAt.Valid {
+		response.FinishedAt = job.FinishedAt.Time.Format(time.RFC3339)
+	}
+
+	return response, nil
+}
+
+// ImportProducts queues the request body as an import file and replies 202 with the job to poll.
+// The format comes from the format query parameter, or else from the Content-Type.
+func (h *ProductImportHandler) ImportProducts(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	format, err := importFormat(r)
+	if err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return apperrors.New(http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE",
+				fmt.Sprintf("Request body must not exceed %d bytes", h.maxBytes))
+		}
+		return apperrors.NewBadRequestError("Failed to read request body")
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return apperrors.NewBadRequestError("Request body is required")
+	}
+
+	ctx := r.Context()
+	job, err := h.importService.CreateImportJob(ctx, userID, format, data)
+	if err != nil {
+		return err
+	}
+
+	response, err := convertImportJobToResponse(job)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", r.URL.Path+"/"+response.ID)
+	w.WriteHeader(http.StatusAccepted)
+	return json.NewEncoder(w).Encode(response)
+}
+
+func (h *ProductImportHandler) GetImportJob(w http.ResponseWriter, r *http.Request) error {
+	if _, err := requireUserID(r); err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	job, err := h.importService.GetImportJobByStringID(ctx, r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	response, err := convertImportJobToResponse(job)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// importFormat reads the format query parameter, falling back to the Content-Type of the body
+func importFormat(r *http.Request) (service.ImportFormat, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return service.ParseImportFormat(format)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return service.ImportFormatCSV, nil
+	case "application/x-ndjson":
+		return service.ImportFormatNDJSON, nil
+	default:
+		return "", service.ErrUnsupportedFormat
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"business-service/internal/service"
+	"pkg/logger"
+)
+
+func TestImportFormat(t *testing.T) {
+	tests := []struct {
+		query, contentType string
+		want               service.ImportFormat
+		wantErr            bool
+	}{
+		{query: "?format=ndjson", contentType: "text/csv", want: service.ImportFormatNDJSON},
+		{contentType: "text/csv; charset=utf-8", want: service.ImportFormatCSV},
+		{contentType: "application/x-ndjson", want: service.ImportFormatNDJSON},
+		{contentType: "application/json", wantErr: true},
+		{query: "?format=xlsx", contentType: "text/csv", wantErr: true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/v1/business/products/imports"+tt.query, nil)
+		r.Header.Set("Content-Type", tt.contentType)
+
+		got, err := importFormat(r)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("importFormat(%q, %q) = %q, %v", tt.query, tt.contentType, got, err)
+		}
+	}
+}
+
+func TestImportProductsRejectsEmptyAndOversizedFiles(t *testing.T) {
+	errorHandler := NewErrorHandler(logger.NewWithWriters("business-service", "error", "json", io.Discard, io.Discard))
+	// The import service is nil, so reaching it would panic
+	h := NewProductImportHandler(nil, 8)
+
+	tests := []struct {
+		body, want string
+	}{
+		{body: " \n", want: "BAD_REQUEST"},
+		{body: "external_sku,name,price,stock\n", want: "REQUEST_TOO_LARGE"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/v1/business/products/imports", strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", "text/csv")
+		r.Header.Set("X-Authenticated-User-ID", "user-1")
+
+		err := h.ImportProducts(httptest.NewRecorder(), r)
+
+		if appErr := errorHandler.Resolve(err); appErr.Code != tt.want {
+			t.Errorf("%q: error = %v, want %s", tt.body, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"pkg/common_handler"
+	apperrors "pkg/errors"
+	"pkg/router"
+)
+
+// Handlers are the handlers served under the versioned API routes
+type Handlers struct {
+	Errors        *common_handler.ErrorHandler
+	Product       *ProductHandler
+	ProductBatch  *ProductBatchHandler
+	ProductExport *ProductExportHandler
+	ProductImport *ProductImportHandler
+	Stock         *StockHandler
+	Inventory     *InventoryHandler
+}
+
+// RegisterRoutes registers every business-service API route on v1.
+// Each route must also be documented in NewOpenAPISpec.
+func RegisterRoutes(v1 *router.VersionedRoutes, h Handlers) {
+	v1.HandleFunc("products", h.Errors.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		switch r.Method {
+		case "GET":
+			// Check if it's a price range query
+			if r.URL.Query().Get("min_price") != "" && r.URL.Query().Get("max_price") != "" {
+				return h.Product.GetProductsByPriceRange(w, r)
+			}
+			return h.Product.GetProducts(w, r)
+		case "POST":
+			return h.Product.CreateProduct(w, r)
+		case "PUT":
+			return h.Product.UpdateProduct(w, r)
+		case "DELETE":
+			return h.Product.DeleteProduct(w, r)
+		default:
+			return apperrors.New(http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		}
+	}))
+	v1.HandleFunc("POST products/batch", h.Errors.Handle(h.ProductBatch.CreateProducts))
+	v1.HandleFunc("PUT products/batch", h.Errors.Handle(h.ProductBatch.UpdateProducts))
+	v1.HandleFunc("DELETE products/batch", h.Errors.Handle(h.ProductBatch.DeleteProducts))
+	v1.HandleFunc("GET products/export", h.Errors.Handle(h.ProductExport.ExportProducts))
+	v1.HandleFunc("POST products/imports", h.Errors.Handle(h.ProductImport.ImportProducts))
+	v1.HandleFunc("GET products/imports/{id}", h.Errors.Handle(h.ProductImport.GetImportJob))
+	v1.HandleFunc("GET products/{id}", h.Errors.Handle(h.Product.GetProduct))
+	v1.HandleFunc("POST products/{id}/stock/adjust", h.Errors.Handle(h.Stock.AdjustStock))
+	v1.HandleFunc("POST products/{id}/stock/reservations", h.Errors.Handle(h.Stock.ReserveStock))
+	v1.HandleFunc("GET products/{id}/stock/movements", h.Errors.Handle(h.Inventory.ListMovements))
+	v1.HandleFunc("GET products/{id}/stock/consistency", h.Errors.Handle(h.Inventory.CheckConsistency))
+	v1.HandleFunc("GET stock/discrepancies", h.Errors.Handle(h.Inventory.ListDiscrepancies))
+	v1.HandleFunc("GET reservations/{id}", h.Errors.Handle(h.Stock.GetReservation))
+	v1.HandleFunc("POST reservations/{id}/confirm", h.Errors.Handle(h.Stock.ConfirmReservation))
+	v1.HandleFunc("POST reservations/{id}/release", h.Errors.Handle(h.Stock.ReleaseReservation))
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"pkg/logger"
+	"pkg/router"
+)
+
+func TestRoutesAreDocumented(t *testing.T) {
+	log := logger.NewWithWriters("business-service", "error", "json", io.Discard, io.Discard)
+	errorHandler := NewErrorHandler(log)
+
+	versions := router.NewVersions(http.NewServeMux(), "business")
+	v1 := versions.Mount("v1", router.VersionPolicy{})
+	RegisterRoutes(v1, Handlers{
+		Errors:        errorHandler,
+		Product:       NewProductHandler(nil),
+		ProductBatch:  NewProductBatchHandler(nil, errorHandler, log, 1, 1),
+		ProductExport: NewProductExportHandler(nil, log),
+		ProductImport: NewProductImportHandler(nil, 1),
+		Stock:         NewStockHandler(nil),
+		Inventory:     NewInventoryHandler(nil, 1),
+	})
+
+	spec := NewOpenAPISpec("business-service", v1)
+	if missing := spec.Undocumented(versions.Patterns()); len(missing) > 0 {
+		t.Errorf("routes missing from the OpenAPI document: %v", missing)
+	}
+}
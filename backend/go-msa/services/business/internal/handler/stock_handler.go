@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"business-service/internal/db"
+	"business-service/internal/service"
+	"github.com/google/uuid"
+	apperrors "pkg/errors"
+	"pkg/validation"
+)
+
+type StockHandler struct {
+	stockService *service.StockService
+}
+
+func NewStockHandler(stockService *service.StockService) *StockHandler {
+	return &StockHandler{
+		stockService: stockService,
+	}
+}
+
+type AdjustStockRequest struct {
+	Delta       int32  `json:"delta"`
+	Reason      string `json:"reason,omitempty" validate:"max=50"`
+	ReferenceID string `json:"reference_id,omitempty" validate:"max=255"`
+}
+
+type ReserveStockRequest struct {
+	Quantity   int32 `json:"quantity"`
+	TTLSeconds int32 `json:"ttl_seconds,omitempty" validate:"min=0"`
+}
+
+type ReservationResponse struct {
+	ID        string `json:"id"`
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// convertReservationToResponse converts db.StockReservation to ReservationResponse
+func convertReservationToResponse(reservation *db.StockReservation) *ReservationResponse {
+	return &ReservationResponse{
+		ID:        reservation.ID.String(),
+		ProductID: reservation.ProductID.String(),
+		Quantity:  reservation.Quantity,
+		Status:    reservation.Status,
+		ExpiresAt: reservation.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedAt: reservation.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: reservation.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// AdjustStock handles POST /products/{id}/stock/adjust
+func (h *StockHandler) AdjustStock(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid product ID format")
+	}
+
+	var req AdjustStockRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		return err
+	}
+
+	if err := validation.New().Check(req.Delta != 0, "delta", "must be non-zero").Err(); err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	product, err := h.stockService.AdjustStock(ctx, id, req.Delta, service.Movement{
+		Reason:      req.Reason,
+		Actor:       userID,
+		ReferenceID: req.ReferenceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	response := convertProductToResponse(product)
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// ReserveStock handles POST /products/{id}/stock/reservations
+func (h *StockHandler) ReserveStock(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid product ID format")
+	}
+
+	var req ReserveStockRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	reservation, err := h.stockService.ReserveStock(ctx, id, req.Quantity, ttl, userID)
+	if err != nil {
+		return err
+	}
+
+	response := convertReservationToResponse(reservation)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// GetReservation handles GET /reservations/{id}
+func (h *StockHandler) GetReservation(w http.ResponseWriter, r *http.Request) error {
+	return h.handleReservation(w, r, func(ctx context.Context, id uuid.UUID, _ string) (*db.StockReservation, error) {
+		return h.stockService.GetReservation(ctx, id)
+	})
+}
+
+// ConfirmReservation handles POST /reservations/{id}/confirm
+func (h *StockHandler) ConfirmReservation(w http.ResponseWriter, r *http.Request) error {
+	return h.handleReservation(w, r, func(ctx context.Context, id uuid.UUID, _ string) (*db.StockReservation, error) {
+		return h.stockService.ConfirmReservation(ctx, id)
+	})
+}
+
+// ReleaseReservation handles POST /reservations/{id}/release
+func (h *StockHandler) ReleaseReservation(w http.ResponseWriter, r *http.Request) error {
+	return h.handleReservation(w, r, h.stockService.ReleaseReservation)
+}
+
+// handleReservation runs a single-reservation operation addressed by the {id} path value
+func (h *StockHandler) handleReservation(
+	w http.ResponseWriter,
+	r *http.Request,
+	op func(ctx context.Context, id uuid.UUID, actor string) (*db.StockReservation, error),
+) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid reservation ID format")
+	}
+
+	reservation, err := op(r.Context(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	response := convertReservationToResponse(reservation)
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"business-service/internal/service"
+	"github.com/google/uuid"
+	"pkg/logger"
+)
+
+func TestStockHandlerRejectsBadRequests(t *testing.T) {
+	errorHandler := NewErrorHandler(logger.NewWithWriters("business-service", "error", "json", io.Discard, io.Discard))
+	// The stock service is nil, so reaching it would panic
+	h := NewStockHandler(nil)
+	productID := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		handler func(http.ResponseWriter, *http.Request) error
+		id      string
+		body    string
+		userID  string
+		want    string
+	}{
+		{name: "adjust without user", handler: h.AdjustStock, id: productID, body: `{"delta":1}`, want: "UNAUTHORIZED"},
+		{name: "adjust bad id", handler: h.AdjustStock, id: "abc", body: `{"delta":1}`, userID: "user-1", want: "BAD_REQUEST"},
+		{name: "adjust zero delta", handler: h.AdjustStock, id: productID, body: `{"delta":0}`, userID: "user-1", want: "VALIDATION_ERROR"},
+		{name: "reserve negative ttl", handler: h.ReserveStock, id: productID, body: `{"quantity":1,"ttl_seconds":-1}`, userID: "user-1", want: "VALIDATION_ERROR"},
+		{name: "confirm bad id", handler: h.ConfirmReservation, id: "abc", userID: "user-1", want: "BAD_REQUEST"},
+		{name: "release without user", handler: h.ReleaseReservation, id: productID, want: "UNAUTHORIZED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/business/stock", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+			if tt.userID != "" {
+				r.Header.Set("X-Authenticated-User-ID", tt.userID)
+			}
+			r.SetPathValue("id", tt.id)
+
+			err := tt.handler(httptest.NewRecorder(), r)
+
+			if appErr := errorHandler.Resolve(err); appErr.Code != tt.want {
+				t.Errorf("error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStockErrorsMapToConflicts(t *testing.T) {
+	errorHandler := NewErrorHandler(logger.NewWithWriters("business-service", "error", "json", io.Discard, io.Discard))
+
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{err: service.ErrInsufficientStock, wantStatus: http.StatusConflict, wantCode: "INSUFFICIENT_STOCK"},
+		{err: service.ErrReservationNotPending, wantStatus: http.StatusConflict, wantCode: "RESERVATION_NOT_PENDING"},
+		{err: service.ErrReservationNotFound, wantStatus: http.StatusNotFound},
+		{err: service.ErrInvalidReservationTTL, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		// The service wraps these with detail, so the mapping must see through it
+		appErr := errorHandler.Resolve(fmt.Errorf("reservation %s: %w", uuid.NewString(), tt.err))
+		if appErr.StatusCode != tt.wantStatus || (tt.wantCode != "" && appErr.Code != tt.wantCode) {
+			t.Errorf("%v resolved to %d %s, want %d %s", tt.err, appErr.StatusCode, appErr.Code, tt.wantStatus, tt.wantCode)
+		}
+	}
+}
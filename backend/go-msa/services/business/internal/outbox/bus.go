@@ -0,0 +1,28 @@
+package outbox
+
+import (
+	"context"
+
+	"pkg/events"
+)
+
+// BusPublisher forwards outbox events to a pkg/events publisher on a single topic
+type BusPublisher struct {
+	publisher events.Publisher
+	topic     string
+}
+
+func NewBusPublisher(publisher events.Publisher, topic string) *BusPublisher {
+	return &BusPublisher{
+		publisher: publisher,
+		topic:     topic,
+	}
+}
+
+func (p *BusPublisher) Publish(ctx context.Context, event Event) error {
+	return p.publisher.Publish(ctx, p.topic, event.Envelope())
+}
+
+func (p *BusPublisher) Close() error {
+	return p.publisher.Close()
+}
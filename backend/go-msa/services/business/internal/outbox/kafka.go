@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// KafkaRESTPublisher publishes events through a Kafka REST Proxy (v2 API).
+// Records are keyed by aggregate ID so events for one product land on the
+// same partition and keep their order.
+type KafkaRESTPublisher struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func NewKafkaRESTPublisher(restURL, topic string) (*KafkaRESTPublisher, error) {
+	base, err := url.Parse(restURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kafka REST URL: %w", err)
+	}
+
+	return &KafkaRESTPublisher{
+		endpoint:   base.JoinPath("topics", topic).String(),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (p *KafkaRESTPublisher) Publish(ctx context.Context, event Event) error {
+	value, err := event.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	body, err := json.Marshal(kafkaProduceRequest{
+		Records: []kafkaRecord{{Key: event.AggregateID.String(), Value: value}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode Kafka request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build Kafka request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish to Kafka: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Kafka REST proxy returned status %d", resp.StatusCode)
+	}
+
+	// The proxy answers 200 even when individual records fail
+	var produced kafkaProduceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&produced); err != nil {
+		return fmt.Errorf("failed to decode Kafka response: %w", err)
+	}
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("Kafka rejected record: %s", offset.Error)
+		}
+	}
+	return nil
+}
+
+func (p *KafkaRESTPublisher) Close() error {
+	p.httpClient.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher hands events to in-process subscribers, for local runs and tests.
+// Nothing is retained: without subscribers, published events are dropped.
+type MemoryPublisher struct {
+	mu       sync.RWMutex
+	handlers []func(Event)
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Subscribe registers a handler that is called synchronously for every published event
+func (p *MemoryPublisher) Subscribe(handler func(Event)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers = append(p.handlers, handler)
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event Event) error {
+	p.mu.RLock()
+	handlers := append([]func(Event){}, p.handlers...)
+	p.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+	return nil
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSPublisher publishes events to a JetStream stream.
+// Subjects are {subjectPrefix}.{event type}, and the event ID is used as the
+// JetStream message ID so redeliveries from the relay are deduplicated.
+type NATSPublisher struct {
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	subjectPrefix string
+}
+
+func NewNATSPublisher(ctx context.Context, url, stream, subjectPrefix string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     stream,
+		Subjects: []string{subjectPrefix + ".>"},
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream stream: %w", err)
+	}
+
+	return &NATSPublisher{
+		conn:          conn,
+		js:            js,
+		subjectPrefix: subjectPrefix,
+	}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := event.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	msg := nats.NewMsg(p.subjectPrefix + "." + event.Type)
+	msg.Data = data
+	msg.Header.Set("Aggregate-Id", event.AggregateID.String())
+
+	if _, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(event.ID.String())); err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"business-service/internal/config"
+	"business-service/internal/db"
+	"github.com/google/uuid"
+	pkgconfig "pkg/config"
+	"pkg/events"
+)
+
+// Event is an outbox row handed to a publisher
+type Event struct {
+	ID            uuid.UUID
+	AggregateType string
+	AggregateID   uuid.UUID
+	Type          string
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+}
+
+// eventSource identifies this service in published envelopes
+const eventSource = "business-service"
+
+// Envelope converts the event to the shared pkg/events envelope.
+// The outbox row ID is reused as the envelope ID so consumers can deduplicate redeliveries.
+func (e Event) Envelope() *events.Event {
+	return &events.Event{
+		SpecVersion: events.SpecVersion,
+		ID:          e.ID.String(),
+		Type:        e.Type,
+		Source:      eventSource,
+		Subject:     e.AggregateType + "/" + e.AggregateID.String(),
+		Time:        e.OccurredAt,
+		Data:        e.Payload,
+	}
+}
+
+// Marshal encodes the event in its wire format
+func (e Event) Marshal() ([]byte, error) {
+	return e.Envelope().Marshal()
+}
+
+// EventPublisher delivers outbox events to a broker.
+// Publish must only return nil once the broker has durably accepted the event,
+// because the relay marks the row as published on success.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
+
+// NewPublisher creates the publisher selected by cfg.Publisher
+func NewPublisher(ctx context.Context, cfg config.OutboxConfig, conn *db.Connection) (EventPublisher, error) {
+	switch cfg.Publisher {
+	case "memory":
+		// Rows would be marked as published without reaching any consumer
+		if pkgconfig.IsProduction() {
+			return nil, fmt.Errorf("outbox publisher %q is not allowed in production", cfg.Publisher)
+		}
+		return NewMemoryPublisher(), nil
+	case "postgres":
+		return NewBusPublisher(events.NewPostgresPublisher(conn.Pool), cfg.Topic), nil
+	case "nats":
+		return NewNATSPublisher(ctx, cfg.NATSURL, cfg.NATSStream, cfg.Topic)
+	case "kafka":
+		return NewKafkaRESTPublisher(cfg.KafkaRESTURL, cfg.Topic)
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher: %q", cfg.Publisher)
+	}
+}
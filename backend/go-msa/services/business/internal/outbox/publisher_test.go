@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+
+	"business-service/internal/config"
+	"github.com/google/uuid"
+)
+
+func TestMemoryPublisherDeliversToSubscribers(t *testing.T) {
+	publisher := NewMemoryPublisher()
+	var received []Event
+	publisher.Subscribe(func(event Event) {
+		received = append(received, event)
+	})
+
+	event := Event{ID: uuid.New(), Type: "product.created"}
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if len(received) != 1 || received[0].ID != event.ID {
+		t.Fatalf("subscriber received %v, want [%v]", received, event)
+	}
+}
+
+func TestNewPublisherRejectsMemoryInProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+
+	if _, err := NewPublisher(context.Background(), config.OutboxConfig{Publisher: "memory"}, nil); err == nil {
+		t.Fatal("NewPublisher() error = nil, want an error in production")
+	}
+}
+
+func TestNewPublisherAllowsMemoryInDevelopment(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	publisher, err := NewPublisher(context.Background(), config.OutboxConfig{Publisher: "memory"}, nil)
+	if err != nil {
+		t.Fatalf("NewPublisher() error = %v", err)
+	}
+	if _, ok := publisher.(*MemoryPublisher); !ok {
+		t.Fatalf("NewPublisher() = %T, want *MemoryPublisher", publisher)
+	}
+}
@@ -0,0 +1,165 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"business-service/internal/config"
+	"business-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"pkg/logger"
+)
+
+// relayLockKey is the pg advisory lock that keeps a single relay publishing at a time
+const relayLockKey int64 = 0x6f7574626f78 // "outbox"
+
+// Relay publishes pending outbox rows and marks them as published. Events of one aggregate are published
+// in commit order; events of different aggregates are not ordered relative to each other.
+// A failed event is retried with exponential backoff, and later events of its aggregate wait for it.
+// Delivery is at-least-once: a crash between Publish and the commit republishes the batch,
+// so consumers must deduplicate by event ID.
+type Relay struct {
+	conn            *db.Connection
+	publisher       EventPublisher
+	pollInterval    time.Duration
+	batchSize       int32
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+	retention       time.Duration
+	cleanupInterval time.Duration
+	log             logger.Logger
+}
+
+func NewRelay(conn *db.Connection, publisher EventPublisher, cfg config.OutboxConfig, log logger.Logger) *Relay {
+	return &Relay{
+		conn:            conn,
+		publisher:       publisher,
+		pollInterval:    cfg.PollInterval,
+		batchSize:       int32(cfg.BatchSize),
+		retryBackoff:    cfg.RetryBackoff,
+		maxRetryBackoff: cfg.MaxRetryBackoff,
+		retention:       cfg.Retention,
+		cleanupInterval: cfg.CleanupInterval,
+		log:             log.With("component", "outbox_relay"),
+	}
+}
+
+// Run relays pending events on every poll and prunes published ones on every cleanup tick until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	pollTicker := time.NewTicker(r.pollInterval)
+	defer pollTicker.Stop()
+	cleanupTicker := time.NewTicker(r.cleanupInterval)
+	defer cleanupTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pollTicker.C:
+			r.drain(ctx)
+		case <-cleanupTicker.C:
+			r.cleanup(ctx)
+		}
+	}
+}
+
+// drain relays batches until the outbox is empty or a batch makes no progress
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		published, err := r.relayBatch(ctx)
+		if err != nil {
+			r.log.Error("Failed to relay outbox events", "error", err)
+			return
+		}
+		if published < int(r.batchSize) {
+			return
+		}
+	}
+}
+
+// relayBatch publishes one batch of pending events inside a transaction holding the relay lock.
+// Once an event fails, later events of the same aggregate are held back so per-aggregate order is kept;
+// the failed event is not listed again until its backoff has passed, so it cannot starve other aggregates.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	published := 0
+	err := r.conn.ExecTx(ctx, func(q *db.Queries) error {
+		locked, err := q.TryOutboxRelayLock(ctx, relayLockKey)
+		if err != nil {
+			return fmt.Errorf("failed to acquire relay lock: %w", err)
+		}
+		if !locked {
+			return nil
+		}
+
+		pending, err := q.ListPendingOutboxEvents(ctx, r.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list pending events: %w", err)
+		}
+
+		blocked := make(map[uuid.UUID]bool)
+		for _, row := range pending {
+			if blocked[row.AggregateID] {
+				continue
+			}
+
+			if err := r.publisher.Publish(ctx, toEvent(row)); err != nil {
+				blocked[row.AggregateID] = true
+				r.log.Warn("Failed to publish outbox event", "event_id", row.ID, "event_type", row.EventType, "error", err)
+				if err := q.RecordOutboxEventFailure(ctx, db.RecordOutboxEventFailureParams{
+					ID:             row.ID,
+					LastError:      pgtype.Text{String: err.Error(), Valid: true},
+					BackoffSeconds: int32(r.backoff(row.Attempts) / time.Second),
+				}); err != nil {
+					return fmt.Errorf("failed to record publish failure: %w", err)
+				}
+				continue
+			}
+
+			if err := q.MarkOutboxEventPublished(ctx, row.ID); err != nil {
+				return fmt.Errorf("failed to mark event published: %w", err)
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
+
+// backoff is the delay before retrying an event that has already been attempted attempts times:
+// retryBackoff doubled per earlier attempt, capped at maxRetryBackoff
+func (r *Relay) backoff(attempts int32) time.Duration {
+	delay := r.retryBackoff
+	for i := int32(0); i < attempts && delay < r.maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return max(min(delay, r.maxRetryBackoff), time.Second)
+}
+
+// cleanup deletes published events older than the retention period
+func (r *Relay) cleanup(ctx context.Context) {
+	deleted, err := r.conn.Queries.DeletePublishedOutboxEvents(ctx, int32(r.retention/time.Second))
+	if err != nil {
+		r.log.Error("Failed to clean up outbox events", "error", err)
+		return
+	}
+	if deleted > 0 {
+		r.log.Info("Deleted published outbox events", "count", deleted)
+	}
+}
+
+func toEvent(row *db.Outbox) Event {
+	return Event{
+		ID:            row.ID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		Type:          row.EventType,
+		Payload:       row.Payload,
+		OccurredAt:    row.CreatedAt,
+	}
+}
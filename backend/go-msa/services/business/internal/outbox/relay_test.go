@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelayBackoff(t *testing.T) {
+	relay := &Relay{retryBackoff: time.Second, maxRetryBackoff: time.Minute}
+
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Second},
+		{attempts: 1, want: 2 * time.Second},
+		{attempts: 3, want: 8 * time.Second},
+		{attempts: 6, want: time.Minute},
+		{attempts: 1000, want: time.Minute},
+	}
+	for _, tt := range tests {
+		if got := relay.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestRelayBackoffIsAtLeastOneSecond(t *testing.T) {
+	// Backoffs are stored in whole seconds, so a shorter one would retry immediately
+	relay := &Relay{retryBackoff: 100 * time.Millisecond, maxRetryBackoff: time.Minute}
+
+	if got := relay.backoff(0); got != time.Second {
+		t.Errorf("backoff(0) = %v, want %v", got, time.Second)
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import "errors"
+
+var (
+	ErrProductNotFound       = errors.New("product not found")
+	ErrInvalidProductID      = errors.New("invalid product ID")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrInvalidQuantity       = errors.New("quantity must be positive")
+	ErrInvalidMovementReason = errors.New("invalid movement reason")
+	ErrInvalidReservationTTL = errors.New("invalid reservation ttl")
+	ErrReservationNotFound   = errors.New("reservation not found")
+	ErrReservationNotPending = errors.New("reservation is no longer pending")
+	ErrImportJobNotFound     = errors.New("import job not found")
+	ErrInvalidImportJobID    = errors.New("invalid import job ID")
+	ErrUnsupportedFormat     = errors.New("unsupported format; use csv or ndjson")
+)
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"business-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Movement reasons stored in inventory_movements.reason
+const (
+	MovementReasonOpeningBalance     = "opening_balance"
+	MovementReasonInitial            = "initial"
+	MovementReasonProductUpdate      = "product_update"
+	MovementReasonAdjustment         = "adjustment"
+	MovementReasonRestock            = "restock"
+	MovementReasonDamage             = "damage"
+	MovementReasonReturn             = "return"
+	MovementReasonReservation        = "reservation"
+	MovementReasonReservationRelease = "reservation_release"
+	MovementReasonReservationExpiry  = "reservation_expiry"
+)
+
+// SystemActor is recorded for movements not triggered by a user, such as expiry sweeps
+const SystemActor = "system"
+
+// manualReasons are the reasons a caller may give when adjusting stock directly
+var manualReasons = map[string]bool{
+	MovementReasonAdjustment: true,
+	MovementReasonRestock:    true,
+	MovementReasonDamage:     true,
+	MovementReasonReturn:     true,
+}
+
+// Movement describes why stock changed and who changed it
+type Movement struct {
+	Reason      string
+	Actor       string
+	ReferenceID string
+}
+
+// StockConsistency compares a product's stock column with the sum of its ledger
+type StockConsistency struct {
+	ProductID   uuid.UUID
+	Stock       int32
+	LedgerStock int32
+}
+
+// Consistent reports whether the stock column matches the ledger
+func (c *StockConsistency) Consistent() bool {
+	return c.Stock == c.LedgerStock
+}
+
+type InventoryService struct {
+	conn *db.Connection
+}
+
+func NewInventoryService(conn *db.Connection) *InventoryService {
+	return &InventoryService{
+		conn: conn,
+	}
+}
+
+// ListMovements retrieves a page of a product's ledger, newest first
+func (s *InventoryService) ListMovements(ctx context.Context, productID uuid.UUID, limit, offset int32) ([]*db.InventoryMovement, error) {
+	if _, err := s.conn.Queries.GetProduct(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	movements, err := s.conn.Queries.ListInventoryMovements(ctx, db.ListInventoryMovementsParams{
+		ProductID: productID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// CheckConsistency recomputes a product's stock from its ledger
+func (s *InventoryService) CheckConsistency(ctx context.Context, productID uuid.UUID) (*StockConsistency, error) {
+	var consistency *StockConsistency
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		// Lock the product so the stock and the ledger are read at the same point
+		product, err := q.GetProductForUpdate(ctx, productID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrProductNotFound
+			}
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+
+		ledgerStock, err := q.GetLedgerStock(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("failed to get ledger stock: %w", err)
+		}
+
+		consistency = &StockConsistency{
+			ProductID:   productID,
+			Stock:       product.Stock,
+			LedgerStock: ledgerStock,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consistency, nil
+}
+
+// DiscrepancyCursor is the position after the last product of a discrepancy page; the zero value starts at the beginning
+type DiscrepancyCursor struct {
+	CreatedAt time.Time
+	ProductID uuid.UUID
+}
+
+// ListDiscrepancies returns up to limit products whose stock disagrees with their ledger, oldest first, starting after
+// the cursor. The returned cursor is nil once the last page has been read.
+func (s *InventoryService) ListDiscrepancies(ctx context.Context, after DiscrepancyCursor, limit int32) ([]*StockConsistency, *DiscrepancyCursor, error) {
+	rows, err := s.conn.Queries.ListStockDiscrepancies(ctx, db.ListStockDiscrepanciesParams{
+		AfterCreatedAt: after.CreatedAt,
+		AfterID:        after.ProductID,
+		RowLimit:       limit,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list stock discrepancies: %w", err)
+	}
+
+	discrepancies := make([]*StockConsistency, len(rows))
+	for i, row := range rows {
+		discrepancies[i] = &StockConsistency{
+			ProductID:   row.ID,
+			Stock:       row.Stock,
+			LedgerStock: row.LedgerStock,
+		}
+	}
+
+	var next *DiscrepancyCursor
+	if len(rows) > 0 && len(rows) == int(limit) {
+		last := rows[len(rows)-1]
+		next = &DiscrepancyCursor{CreatedAt: last.CreatedAt, ProductID: last.ID}
+	}
+
+	return discrepancies, next, nil
+}
+
+// recordMovement appends a ledger entry; it must run in the transaction that changed the stock
+func recordMovement(ctx context.Context, q *db.Queries, productID uuid.UUID, delta int32, movement Movement) error {
+	if delta == 0 {
+		return nil
+	}
+
+	actor := movement.Actor
+	if actor == "" {
+		actor = SystemActor
+	}
+
+	_, err := q.CreateInventoryMovement(ctx, db.CreateInventoryMovementParams{
+		ProductID:   productID,
+		Delta:       delta,
+		Reason:      movement.Reason,
+		Actor:       actor,
+		ReferenceID: pgtype.Text{String: movement.ReferenceID, Valid: movement.ReferenceID != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record inventory movement: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestListDiscrepanciesPages(t *testing.T) {
+	conn := newTestConnection(t)
+	inventory := NewInventoryService(conn)
+	ctx := context.Background()
+
+	// Products inserted directly have no ledger, so any stock is a discrepancy
+	want := map[uuid.UUID]bool{}
+	for i := 0; i < 5; i++ {
+		want[createTestProduct(t, conn, 3)] = true
+	}
+	createTestProduct(t, conn, 0)
+
+	seen := map[uuid.UUID]bool{}
+	var after DiscrepancyCursor
+	for pages := 1; ; pages++ {
+		page, next, err := inventory.ListDiscrepancies(ctx, after, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) > 2 {
+			t.Fatalf("page %d has %d rows, want at most 2", pages, len(page))
+		}
+		for _, discrepancy := range page {
+			if seen[discrepancy.ProductID] {
+				t.Errorf("product %s listed twice", discrepancy.ProductID)
+			}
+			seen[discrepancy.ProductID] = true
+		}
+		if next == nil {
+			break
+		}
+		if pages > len(want) {
+			t.Fatal("pagination did not end")
+		}
+		after = *next
+	}
+
+	if len(seen) != len(want) {
+		t.Errorf("listed %d discrepancies, want %d", len(seen), len(want))
+	}
+	for id := range seen {
+		if !want[id] {
+			t.Errorf("consistent product %s was listed", id)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"business-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AggregateTypeProduct is stored in outbox.aggregate_type for product events
+const AggregateTypeProduct = "product"
+
+// Product event types written to the outbox
+const (
+	EventProductCreated      = "product.created"
+	EventProductUpdated      = "product.updated"
+	EventProductDeleted      = "product.deleted"
+	EventProductStockChanged = "product.stock_changed"
+)
+
+// ProductEvent is the payload of product.created and product.updated
+type ProductEvent struct {
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Price       pgtype.Numeric `json:"price"`
+	Stock       int32          `json:"stock"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// ProductDeletedEvent is the payload of product.deleted
+type ProductDeletedEvent struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// StockChangedEvent is the payload of product.stock_changed
+type StockChangedEvent struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	Delta       int32     `json:"delta"`
+	Stock       int32     `json:"stock"`
+	Reason      string    `json:"reason"`
+	Actor       string    `json:"actor"`
+	ReferenceID string    `json:"reference_id,omitempty"`
+}
+
+func newProductEvent(product *db.Product) ProductEvent {
+	return ProductEvent{
+		ID:          product.ID,
+		Name:        product.Name,
+		Description: product.Description.String,
+		Price:       product.Price,
+		Stock:       product.Stock,
+		UpdatedAt:   product.UpdatedAt,
+	}
+}
+
+// enqueueEvent writes an event to the outbox; q must be bound to the transaction that made the change
+func enqueueEvent(ctx context.Context, q *db.Queries, productID uuid.UUID, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	_, err = q.CreateOutboxEvent(ctx, db.CreateOutboxEventParams{
+		AggregateType: AggregateTypeProduct,
+		AggregateID:   productID,
+		EventType:     eventType,
+		Payload:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", eventType, err)
+	}
+
+	return nil
+}
+
+// recordStockChange records a stock delta in the ledger and announces it through the outbox.
+// product must reflect the stock after the change.
+func recordStockChange(ctx context.Context, q *db.Queries, product *db.Product, delta int32, movement Movement) error {
+	if delta == 0 {
+		return nil
+	}
+
+	if err := recordMovement(ctx, q, product.ID, delta, movement); err != nil {
+		return err
+	}
+
+	actor := movement.Actor
+	if actor == "" {
+		actor = SystemActor
+	}
+
+	return enqueueEvent(ctx, q, product.ID, EventProductStockChanged, StockChangedEvent{
+		ProductID:   product.ID,
+		Delta:       delta,
+		Stock:       product.Stock,
+		Reason:      movement.Reason,
+		Actor:       actor,
+		ReferenceID: movement.ReferenceID,
+	})
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"business-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// BatchMode decides what happens to the rest of a bulk operation when an item fails
+type BatchMode string
+
+const (
+	// BatchAtomic applies every item in one transaction, or none of them
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort commits chunk by chunk and keeps the items that succeed
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// BatchItemStatus is the outcome of a single item of a bulk operation
+type BatchItemStatus string
+
+const (
+	BatchItemSucceeded BatchItemStatus = "succeeded"
+	BatchItemFailed    BatchItemStatus = "failed"
+	// BatchItemRolledBack marks an item that was applied and then undone because another item failed
+	BatchItemRolledBack BatchItemStatus = "rolled_back"
+	// BatchItemSkipped marks an item that was never attempted
+	BatchItemSkipped BatchItemStatus = "skipped"
+)
+
+// ProductInput is one item of a bulk create or update. ID is ignored on create.
+type ProductInput struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Price       float64
+	Stock       int32
+}
+
+// BatchItemResult is the outcome of one input item; Product is set for succeeded creates and updates
+type BatchItemResult struct {
+	Status  BatchItemStatus
+	Product *db.Product
+	Err     error
+}
+
+// errBatchItemFailed aborts an atomic batch once an item has failed
+var errBatchItemFailed = errors.New("batch item failed")
+
+// productCopyColumns are the products columns CreateProducts fills with COPY
+var productCopyColumns = []string{"id", "name", "description", "price", "stock"}
+
+// batchStep applies items of a bulk operation. bulk, when set, applies a whole chunk at once
+// and item is used to find the failing items when it fails.
+type batchStep struct {
+	bulk func(ctx context.Context, tx pgx.Tx, indexes []int) ([]*db.Product, error)
+	item func(ctx context.Context, tx pgx.Tx, index int) (*db.Product, error)
+}
+
+// CreateProducts creates products in chunks, inserting each chunk with COPY
+func (s *ProductService) CreateProducts(ctx context.Context, actor string, mode BatchMode, inputs []ProductInput) []BatchItemResult {
+	bulk := func(ctx context.Context, tx pgx.Tx, indexes []int) ([]*db.Product, error) {
+		chunk := make([]ProductInput, len(indexes))
+		for k, i := range indexes {
+			chunk[k] = inputs[i]
+		}
+		return s.copyProducts(ctx, tx, actor, chunk)
+	}
+
+	return s.runBatch(ctx, mode, len(inputs), batchStep{
+		bulk: bulk,
+		item: func(ctx context.Context, tx pgx.Tx, index int) (*db.Product, error) {
+			products, err := bulk(ctx, tx, []int{index})
+			if err != nil {
+				return nil, err
+			}
+			return products[0], nil
+		},
+	})
+}
+
+// UpdateProducts overwrites products by ID, recording any stock difference in the ledger
+func (s *ProductService) UpdateProducts(ctx context.Context, actor string, mode BatchMode, inputs []ProductInput) []BatchItemResult {
+	return s.runBatch(ctx, mode, len(inputs), batchStep{
+		item: func(ctx context.Context, tx pgx.Tx, index int) (*db.Product, error) {
+			input := inputs[index]
+			params := db.UpdateProductParams{
+				ID:          input.ID,
+				Name:        input.Name,
+				Description: pgtype.Text{String: input.Description, Valid: input.Description != ""},
+				Stock:       input.Stock,
+			}
+			if err := params.Price.Scan(fmt.Sprintf("%.2f", input.Price)); err != nil {
+				return nil, fmt.Errorf("failed to convert price: %w", err)
+			}
+
+			return updateProduct(ctx, s.queries.WithTx(tx), actor, params)
+		},
+	})
+}
+
+// DeleteProducts deletes products by ID and announces each deletion through the outbox
+func (s *ProductService) DeleteProducts(ctx context.Context, mode BatchMode, ids []uuid.UUID) []BatchItemResult {
+	return s.runBatch(ctx, mode, len(ids), batchStep{
+		item: func(ctx context.Context, tx pgx.Tx, index int) (*db.Product, error) {
+			return nil, deleteProduct(ctx, s.queries.WithTx(tx), ids[index])
+		},
+	})
+}
+
+// runBatch applies n items in chunks. Atomic batches share one transaction and stop after the first failing chunk;
+// best-effort batches commit each chunk separately.
+func (s *ProductService) runBatch(ctx context.Context, mode BatchMode, n int, step batchStep) []BatchItemResult {
+	results := make([]BatchItemResult, n)
+	for i := range results {
+		results[i].Status = BatchItemSkipped
+	}
+
+	chunks := chunkIndexes(n, s.chunkSize)
+
+	if mode == BatchAtomic {
+		err := s.conn.ExecRawTx(ctx, func(tx pgx.Tx) error {
+			for _, chunk := range chunks {
+				if !runChunk(ctx, tx, step, chunk, results) {
+					return errBatchItemFailed
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			for i := range results {
+				switch {
+				case errors.Is(err, errBatchItemFailed) && results[i].Status == BatchItemSucceeded:
+					results[i] = BatchItemResult{Status: BatchItemRolledBack}
+				case !errors.Is(err, errBatchItemFailed) && results[i].Status != BatchItemFailed:
+					// Beginning or committing the transaction failed, so nothing was applied
+					results[i] = BatchItemResult{Status: BatchItemFailed, Err: err}
+				}
+			}
+		}
+		return results
+	}
+
+	for _, chunk := range chunks {
+		err := s.conn.ExecRawTx(ctx, func(tx pgx.Tx) error {
+			runChunk(ctx, tx, step, chunk, results)
+			return nil
+		})
+		if err != nil {
+			for _, i := range chunk {
+				results[i] = BatchItemResult{Status: BatchItemFailed, Err: err}
+			}
+		}
+	}
+
+	return results
+}
+
+// runChunk applies the chunk's items within tx, each failure confined to its own savepoint,
+// and reports whether all of them succeeded
+func runChunk(ctx context.Context, tx pgx.Tx, step batchStep, chunk []int, results []BatchItemResult) bool {
+	if step.bulk != nil && len(chunk) > 1 {
+		var products []*db.Product
+		err := inSavepoint(ctx, tx, func(tx pgx.Tx) error {
+			var err error
+			products, err = step.bulk(ctx, tx, chunk)
+			return err
+		})
+		if err == nil {
+			for k, i := range chunk {
+				results[i] = BatchItemResult{Status: BatchItemSucceeded, Product: products[k]}
+			}
+			return true
+		}
+		// Fall through and retry item by item to find out which items caused the failure
+	}
+
+	ok := true
+	for _, i := range chunk {
+		var product *db.Product
+		err := inSavepoint(ctx, tx, func(tx pgx.Tx) error {
+			var err error
+			product, err = step.item(ctx, tx, i)
+			return err
+		})
+		if err != nil {
+			results[i] = BatchItemResult{Status: BatchItemFailed, Err: err}
+			ok = false
+			continue
+		}
+		results[i] = BatchItemResult{Status: BatchItemSucceeded, Product: product}
+	}
+
+	return ok
+}
+
+// copyProducts inserts products with COPY, then enqueues their events and records their initial stock
+func (s *ProductService) copyProducts(ctx context.Context, tx pgx.Tx, actor string, inputs []ProductInput) ([]*db.Product, error) {
+	ids := make([]uuid.UUID, len(inputs))
+	rows := make([][]any, len(inputs))
+	for k, input := range inputs {
+		var price pgtype.Numeric
+		if err := price.Scan(fmt.Sprintf("%.2f", input.Price)); err != nil {
+			return nil, fmt.Errorf("failed to convert price: %w", err)
+		}
+
+		ids[k] = uuid.New()
+		rows[k] = []any{
+			ids[k],
+			input.Name,
+			pgtype.Text{String: input.Description, Valid: input.Description != ""},
+			price,
+			input.Stock,
+		}
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"products"}, productCopyColumns, pgx.CopyFromRows(rows)); err != nil {
+		return nil, fmt.Errorf("failed to copy products: %w", err)
+	}
+
+	q := s.queries.WithTx(tx)
+	created, err := q.GetProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created products: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*db.Product, len(created))
+	for _, product := range created {
+		byID[product.ID] = product
+	}
+
+	products := make([]*db.Product, len(ids))
+	for k, id := range ids {
+		product, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("failed to get created product %s", id)
+		}
+
+		if err := enqueueEvent(ctx, q, id, EventProductCreated, newProductEvent(product)); err != nil {
+			return nil, err
+		}
+
+		err := recordStockChange(ctx, q, product, product.Stock, Movement{
+			Reason: MovementReasonInitial,
+			Actor:  actor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		products[k] = product
+	}
+
+	return products, nil
+}
+
+// inSavepoint runs fn in a savepoint of tx so that a failure only undoes fn's own work
+func inSavepoint(ctx context.Context, tx pgx.Tx, fn func(tx pgx.Tx) error) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(savepoint); err != nil {
+		_ = savepoint.Rollback(ctx)
+		return err
+	}
+
+	if err := savepoint.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return nil
+}
+
+// chunkIndexes splits the indexes 0..n-1 into consecutive chunks of at most size
+func chunkIndexes(n, size int) [][]int {
+	if size <= 0 {
+		size = n
+	}
+
+	var chunks [][]int
+	for start := 0; start < n; start += size {
+		end := min(start+size, n)
+		chunk := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			chunk = append(chunk, i)
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
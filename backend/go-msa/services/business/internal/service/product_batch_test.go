@@ -0,0 +1,24 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChunkIndexes(t *testing.T) {
+	tests := []struct {
+		n, size int
+		want    [][]int
+	}{
+		{n: 0, size: 2, want: nil},
+		{n: 3, size: 5, want: [][]int{{0, 1, 2}}},
+		{n: 5, size: 2, want: [][]int{{0, 1}, {2, 3}, {4}}},
+		{n: 4, size: 2, want: [][]int{{0, 1}, {2, 3}}},
+		{n: 3, size: 0, want: [][]int{{0, 1, 2}}},
+	}
+	for _, tt := range tests {
+		if got := chunkIndexes(tt.n, tt.size); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("chunkIndexes(%d, %d) = %v, want %v", tt.n, tt.size, got, tt.want)
+		}
+	}
+}
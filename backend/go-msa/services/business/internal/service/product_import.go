@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"business-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"pkg/logger"
+)
+
+// ImportFormat is the file format of a product import or export
+type ImportFormat string
+
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+)
+
+// Import job statuses
+const (
+	ImportJobPending = "pending"
+	ImportJobRunning = "running"
+	// ImportJobCompleted means every row was processed; some may have been rejected
+	ImportJobCompleted = "completed"
+	// ImportJobFailed means the file could not be processed; LastError says why
+	ImportJobFailed = "failed"
+)
+
+// errImportJobLost means another worker took the job over after it went stale; the current worker's writes are discarded
+var errImportJobLost = errors.New("import job was taken over by another worker")
+
+// ParseImportFormat parses a format name
+func ParseImportFormat(value string) (ImportFormat, error) {
+	switch format := ImportFormat(value); format {
+	case ImportFormatCSV, ImportFormatNDJSON:
+		return format, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ProductImportService accepts product import files and applies them in the background.
+// Rows are upserted by external SKU in chunks; each chunk commits together with the job's progress,
+// so a job interrupted by a restart resumes after the last committed chunk.
+type ProductImportService struct {
+	conn        *db.Connection
+	queries     *db.Queries
+	chunkSize   int
+	maxErrors   int
+	maxAttempts int32
+	staleAfter  time.Duration
+	log         logger.Logger
+}
+
+// NewProductImportService creates a ProductImportService. At most maxErrors rejected rows are described per job;
+// a running job whose progress has not moved for staleAfter is taken over by another worker, up to maxAttempts times.
+func NewProductImportService(conn *db.Connection, chunkSize, maxErrors, maxAttempts int, staleAfter time.Duration, log logger.Logger) *ProductImportService {
+	return &ProductImportService{
+		conn:        conn,
+		queries:     conn.Queries,
+		chunkSize:   max(chunkSize, 1),
+		maxErrors:   maxErrors,
+		maxAttempts: int32(maxAttempts),
+		staleAfter:  staleAfter,
+		log:         log.With("component", "product_importer"),
+	}
+}
+
+// CreateImportJob stores an import file and queues it for processing
+func (s *ProductImportService) CreateImportJob(ctx context.Context, actor string, format ImportFormat, data []byte) (*db.ProductImportJob, error) {
+	var job *db.ProductImportJob
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		job, err = q.CreateProductImportJob(ctx, db.CreateProductImportJobParams{
+			Format: string(format),
+			Actor:  actor,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create import job: %w", err)
+		}
+
+		err = q.CreateProductImportPayload(ctx, db.CreateProductImportPayloadParams{
+			JobID: job.ID,
+			Data:  data,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store import file: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetImportJobByStringID retrieves an import job by string ID (converts to UUID)
+func (s *ProductImportService) GetImportJobByStringID(ctx context.Context, idStr string) (*db.ProductImportJob, error) {
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportJobID, err)
+	}
+
+	job, err := s.queries.GetProductImportJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ProcessNextJob claims the oldest pending or abandoned job and runs it to completion.
+// It reports false when there was nothing to claim.
+func (s *ProductImportService) ProcessNextJob(ctx context.Context) (bool, error) {
+	job, err := s.queries.ClaimProductImportJob(ctx, int32(s.staleAfter.Seconds()))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim import job: %w", err)
+	}
+
+	log := s.log.With("job_id", job.ID)
+	if job.Attempts > s.maxAttempts {
+		log.Warn("Giving up on import job", "attempts", job.Attempts-1)
+		err = s.finish(ctx, job, ImportJobFailed, fmt.Sprintf("gave up after %d attempts", job.Attempts-1))
+	} else {
+		log.Info("Processing import job", "attempt", job.Attempts, "resume_after_rows", job.ProcessedRows)
+		err = s.process(ctx, job)
+	}
+	if errors.Is(err, errImportJobLost) {
+		log.Warn("Import job was taken over by another worker", "attempt", job.Attempts)
+		return true, nil
+	}
+	if err != nil {
+		// The job stays running and is taken over once it goes stale
+		return true, fmt.Errorf("failed to process import job %s: %w", job.ID, err)
+	}
+
+	return true, nil
+}
+
+// process applies the rows of job that are not yet processed, chunk by chunk
+func (s *ProductImportService) process(ctx context.Context, job *db.ProductImportJob) error {
+	data, err := s.queries.GetProductImportPayload(ctx, job.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return s.finish(ctx, job, ImportJobFailed, "import file is missing")
+		}
+		return fmt.Errorf("failed to get import file: %w", err)
+	}
+
+	reader, err := newRowReader(ImportFormat(job.Format), data)
+	if err != nil {
+		return s.finish(ctx, job, ImportJobFailed, err.Error())
+	}
+
+	// Rows committed by an earlier attempt are read again but not applied
+	for skip := job.ProcessedRows; skip > 0; skip-- {
+		if _, _, _, err := reader.Next(); err != nil {
+			return s.finish(ctx, job, ImportJobFailed, fmt.Sprintf("import file changed: %v", err))
+		}
+	}
+
+	failedRows := int(job.FailedRows)
+	for {
+		chunk, readErr := s.readChunk(reader)
+		if len(chunk) > 0 {
+			if err := s.applyChunk(ctx, job, chunk, &failedRows); err != nil {
+				return err
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			return s.finish(ctx, job, ImportJobCompleted, "")
+		}
+		if readErr != nil {
+			return s.finish(ctx, job, ImportJobFailed, fmt.Sprintf("failed to read import file: %v", readErr))
+		}
+	}
+}
+
+// importChunkRow is a row read from the file, either parsed or rejected
+type importChunkRow struct {
+	line   int
+	row    *ImportRow
+	errors []ImportRowError
+}
+
+// readChunk reads up to chunkSize rows, returning the reader's error once it stops
+func (s *ProductImportService) readChunk(reader rowReader) ([]importChunkRow, error) {
+	chunk := make([]importChunkRow, 0, s.chunkSize)
+	for len(chunk) < s.chunkSize {
+		line, row, rowErrors, err := reader.Next()
+		if err != nil {
+			return chunk, err
+		}
+		chunk = append(chunk, importChunkRow{line: line, row: row, errors: rowErrors})
+	}
+	return chunk, nil
+}
+
+// applyChunk upserts the chunk's valid rows, each in its own savepoint, and records the job's progress in the same transaction.
+// failedRows counts the job's rejected rows so far; only the first maxErrors of them are described.
+func (s *ProductImportService) applyChunk(ctx context.Context, job *db.ProductImportJob, chunk []importChunkRow, failedRows *int) error {
+	return s.conn.ExecRawTx(ctx, func(tx pgx.Tx) error {
+		params := db.RecordProductImportProgressParams{
+			ID:        job.ID,
+			Attempts:  job.Attempts,
+			Processed: int32(len(chunk)),
+		}
+		rowErrors := []ImportRowError{}
+		failed := *failedRows
+
+		for _, item := range chunk {
+			if len(item.errors) == 0 {
+				var created bool
+				err := inSavepoint(ctx, tx, func(tx pgx.Tx) error {
+					var err error
+					created, err = s.upsertRow(ctx, s.queries.WithTx(tx), job.Actor, item.row)
+					return err
+				})
+				switch {
+				case err == nil && created:
+					params.Created++
+					continue
+				case err == nil:
+					params.Updated++
+					continue
+				default:
+					s.log.Error("Failed to import row", "job_id", job.ID, "line", item.line, "error", err)
+					item.errors = []ImportRowError{{Line: item.line, Message: "could not be saved"}}
+				}
+			}
+
+			params.Failed++
+			if failed < s.maxErrors {
+				rowErrors = append(rowErrors, item.errors...)
+			}
+			failed++
+		}
+
+		var err error
+		params.Errors, err = json.Marshal(rowErrors)
+		if err != nil {
+			return fmt.Errorf("failed to encode row errors: %w", err)
+		}
+		recorded, err := s.queries.WithTx(tx).RecordProductImportProgress(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to record import progress: %w", err)
+		}
+		if recorded == 0 {
+			// Rolls back the chunk's upserts; the new owner applies them again
+			return errImportJobLost
+		}
+
+		*failedRows = failed
+		return nil
+	})
+}
+
+// upsertRow updates the product registered under the row's SKU, or creates it and registers the SKU.
+// Either way the change goes through the outbox and the stock ledger like any other product write.
+func (s *ProductImportService) upsertRow(ctx context.Context, q *db.Queries, actor string, row *ImportRow) (bool, error) {
+	description := pgtype.Text{String: row.Description, Valid: row.Description != ""}
+	var price pgtype.Numeric
+	if err := price.Scan(fmt.Sprintf("%.2f", row.Price)); err != nil {
+		return false, fmt.Errorf("failed to convert price: %w", err)
+	}
+
+	id, err := q.GetProductIDBySKUForUpdate(ctx, row.ExternalSKU)
+	switch {
+	case err == nil:
+		_, err := updateProduct(ctx, q, actor, db.UpdateProductParams{
+			ID:          id,
+			Name:        row.Name,
+			Description: description,
+			Price:       price,
+			Stock:       row.Stock,
+		})
+		return false, err
+	case !errors.Is(err, pgx.ErrNoRows):
+		return false, fmt.Errorf("failed to get product by SKU: %w", err)
+	}
+
+	product, err := q.CreateProductWithID(ctx, db.CreateProductWithIDParams{
+		ID:          uuid.New(),
+		Name:        row.Name,
+		Description: description,
+		Price:       price,
+		Stock:       row.Stock,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to create product: %w", err)
+	}
+
+	err = q.CreateProductSKU(ctx, db.CreateProductSKUParams{
+		ExternalSku: row.ExternalSKU,
+		ProductID:   product.ID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to register SKU: %w", err)
+	}
+
+	if err := enqueueEvent(ctx, q, product.ID, EventProductCreated, newProductEvent(product)); err != nil {
+		return false, err
+	}
+
+	err = recordStockChange(ctx, q, product, product.Stock, Movement{
+		Reason: MovementReasonInitial,
+		Actor:  actor,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// finish marks a job as done and drops its file, unless another worker has taken the job over
+func (s *ProductImportService) finish(ctx context.Context, job *db.ProductImportJob, status, lastError string) error {
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		finished, err := q.FinishProductImportJob(ctx, db.FinishProductImportJobParams{
+			ID:        job.ID,
+			Status:    status,
+			LastError: pgtype.Text{String: lastError, Valid: lastError != ""},
+			Attempts:  job.Attempts,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to finish import job: %w", err)
+		}
+		if finished == 0 {
+			return errImportJobLost
+		}
+
+		if err := q.DeleteProductImportPayload(ctx, job.ID); err != nil {
+			return fmt.Errorf("failed to delete import file: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if status == ImportJobFailed {
+		s.log.Warn("Import job failed", "job_id", job.ID, "error", lastError)
+	} else {
+		s.log.Info("Import job completed", "job_id", job.ID)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"pkg/validation"
+)
+
+// ImportRow is one product row of an import file, matched to an existing product by ExternalSKU
+type ImportRow struct {
+	ExternalSKU string  `json:"external_sku" validate:"required,max=100"`
+	Name        string  `json:"name" validate:"required,max=255"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price" validate:"min=0,max=99999999.99"`
+	Stock       int32   `json:"stock" validate:"min=0"`
+}
+
+// ImportRowError reports why a line of an import file was rejected
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// importColumns are the CSV header names; every column but description is required
+var importColumns = []string{"external_sku", "name", "description", "price", "stock"}
+
+// exportOnlyColumns are written by exports and ignored by imports, so an export can be edited and imported back
+var exportOnlyColumns = []string{"id", "created_at", "updated_at"}
+
+// rowReader yields the rows of an import file. Next returns the row's line and either the row or the reasons
+// it was rejected, and io.EOF after the last row. Any other error means the file cannot be read further.
+type rowReader interface {
+	Next() (line int, row *ImportRow, rowErrors []ImportRowError, err error)
+}
+
+func newRowReader(format ImportFormat, data []byte) (rowReader, error) {
+	switch format {
+	case ImportFormatCSV:
+		return newCSVRowReader(data)
+	case ImportFormatNDJSON:
+		return &ndjsonRowReader{reader: bufio.NewReader(bytes.NewReader(data))}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// csvRowReader reads CSV with a header row naming the columns in any order
+type csvRowReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVRowReader(data []byte) (*csvRowReader, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file is empty")
+		}
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet applications often prefix UTF-8 files with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if slices.Contains(exportOnlyColumns, name) {
+			continue
+		}
+		if !slices.Contains(importColumns, name) {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range importColumns {
+		if _, ok := columns[name]; !ok && name != "description" {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	return &csvRowReader{reader: reader, columns: columns}, nil
+}
+
+func (c *csvRowReader) Next() (int, *ImportRow, []ImportRowError, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return parseErr.StartLine, nil, []ImportRowError{{Line: parseErr.StartLine, Message: parseErr.Err.Error()}}, nil
+		}
+		return 0, nil, nil, err
+	}
+
+	line, _ := c.reader.FieldPos(0)
+	value := func(name string) string {
+		if i, ok := c.columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := &ImportRow{
+		ExternalSKU: value("external_sku"),
+		Name:        value("name"),
+		Description: value("description"),
+	}
+
+	var rowErrors []ImportRowError
+	if price, err := strconv.ParseFloat(value("price"), 64); err != nil {
+		rowErrors = append(rowErrors, ImportRowError{Line: line, Field: "price", Message: "must be a number"})
+	} else {
+		row.Price = price
+	}
+	if stock, err := strconv.ParseInt(value("stock"), 10, 32); err != nil {
+		rowErrors = append(rowErrors, ImportRowError{Line: line, Field: "stock", Message: "must be an integer"})
+	} else {
+		row.Stock = int32(stock)
+	}
+
+	return line, row, append(rowErrors, validateImportRow(line, row)...), nil
+}
+
+// ndjsonRowReader reads one JSON object per line, skipping blank lines
+type ndjsonRowReader struct {
+	reader *bufio.Reader
+	line   int
+}
+
+func (n *ndjsonRowReader) Next() (int, *ImportRow, []ImportRowError, error) {
+	for {
+		data, err := n.reader.ReadBytes('\n')
+		if len(data) == 0 && err != nil {
+			return 0, nil, nil, err
+		}
+		n.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+
+		var line ndjsonImportLine
+		if err := decoder.Decode(&line); err != nil {
+			return n.line, nil, []ImportRowError{ndjsonRowError(n.line, err)}, nil
+		}
+		if decoder.More() {
+			return n.line, nil, []ImportRowError{{Line: n.line, Message: "must contain a single JSON object"}}, nil
+		}
+
+		return n.line, &line.ImportRow, validateImportRow(n.line, &line.ImportRow), nil
+	}
+}
+
+// ndjsonImportLine accepts the export-only fields next to the row
+type ndjsonImportLine struct {
+	ImportRow
+	ID        json.RawMessage `json:"id"`
+	CreatedAt json.RawMessage `json:"created_at"`
+	UpdatedAt json.RawMessage `json:"updated_at"`
+}
+
+// ndjsonRowError describes a JSON decoding failure of one line
+func ndjsonRowError(line int, err error) ImportRowError {
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return ImportRowError{Line: line, Field: typeErr.Field, Message: "has the wrong type"}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return ImportRowError{Line: line, Field: field, Message: "is not allowed"}
+	default:
+		return ImportRowError{Line: line, Message: "must be a JSON object"}
+	}
+}
+
+// validateImportRow applies the row's tag rules
+func validateImportRow(line int, row *ImportRow) []ImportRowError {
+	var rowErrors []ImportRowError
+	for _, fieldErr := range validation.New().Struct(row).Errors() {
+		rowErrors = append(rowErrors, ImportRowError{Line: line, Field: fieldErr.Field, Message: fieldErr.Message})
+	}
+	return rowErrors
+}
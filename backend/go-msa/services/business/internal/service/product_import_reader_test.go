@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readResult is a row of an import file reduced to what the tests compare
+type readResult struct {
+	line   int
+	sku    string
+	fields []string
+}
+
+func readAllRows(t *testing.T, reader rowReader) []readResult {
+	t.Helper()
+	var results []readResult
+	for {
+		line, row, rowErrors, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return results
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+
+		result := readResult{line: line}
+		if row != nil {
+			result.sku = row.ExternalSKU
+		}
+		for _, rowErr := range rowErrors {
+			if rowErr.Line != line {
+				t.Errorf("error line = %d, want %d", rowErr.Line, line)
+			}
+			result.fields = append(result.fields, rowErr.Field)
+		}
+		results = append(results, result)
+	}
+}
+
+func TestCSVRowReader(t *testing.T) {
+	data := "\ufeffName, EXTERNAL_SKU,price,stock,id\n" +
+		"Widget,SKU-1,9.99,5,ignored\n" +
+		"Gadget,SKU-2,abc,-1,ignored\n" +
+		",SKU-3,1,1,ignored\n" +
+		"Bolt,SKU-4,1\n"
+
+	reader, err := newRowReader(ImportFormatCSV, []byte(data))
+	if err != nil {
+		t.Fatalf("newRowReader() error = %v", err)
+	}
+
+	want := []readResult{
+		{line: 2, sku: "SKU-1"},
+		{line: 3, sku: "SKU-2", fields: []string{"price", "stock"}},
+		{line: 4, sku: "SKU-3", fields: []string{"name"}},
+		{line: 5, fields: []string{""}},
+	}
+	if got := readAllRows(t, reader); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %+v, want %+v", got, want)
+	}
+}
+
+func TestCSVRowReaderHeader(t *testing.T) {
+	tests := []struct {
+		name, data, want string
+	}{
+		{name: "empty", data: "", want: "file is empty"},
+		{name: "unknown column", data: "external_sku,name,price,stock,color\n", want: `unknown column "color"`},
+		{name: "duplicate column", data: "external_sku,name,price,stock,name\n", want: `duplicate column "name"`},
+		{name: "missing column", data: "external_sku,name,price\n", want: `missing column "stock"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newRowReader(ImportFormatCSV, []byte(tt.data))
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := newRowReader(ImportFormatCSV, []byte("external_sku,name,price,stock\n")); err != nil {
+		t.Errorf("description was required: %v", err)
+	}
+}
+
+func TestNDJSONRowReader(t *testing.T) {
+	data := strings.Join([]string{
+		`{"external_sku":"SKU-1","name":"Widget","price":1,"stock":2,"id":"ignored"}`,
+		``,
+		`{"external_sku":"SKU-2","name":"Widget","price":"1","stock":2}`,
+		`{"external_sku":"SKU-3","name":"Widget","price":1,"stock":2,"color":"red"}`,
+		`not json`,
+		`{"external_sku":"SKU-4","name":"Widget","price":1,"stock":2} {}`,
+		`{"external_sku":"","name":"Widget","price":-1,"stock":2}`,
+	}, "\n")
+
+	reader, err := newRowReader(ImportFormatNDJSON, []byte(data))
+	if err != nil {
+		t.Fatalf("newRowReader() error = %v", err)
+	}
+
+	want := []readResult{
+		{line: 1, sku: "SKU-1"},
+		{line: 3, fields: []string{"price"}},
+		{line: 4, fields: []string{"color"}},
+		{line: 5, fields: []string{""}},
+		{line: 6, fields: []string{""}},
+		{line: 7, fields: []string{"external_sku", "price"}},
+	}
+	if got := readAllRows(t, reader); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseImportFormat(t *testing.T) {
+	for _, value := range []string{"csv", "ndjson"} {
+		if format, err := ParseImportFormat(value); err != nil || string(format) != value {
+			t.Errorf("ParseImportFormat(%q) = %q, %v", value, format, err)
+		}
+	}
+	if _, err := ParseImportFormat("xlsx"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("ParseImportFormat(xlsx) error = %v, want ErrUnsupportedFormat", err)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"pkg/logger"
+)
+
+// ProductImporter periodically runs queued product import jobs
+type ProductImporter struct {
+	importService *ProductImportService
+	interval      time.Duration
+	log           logger.Logger
+}
+
+func NewProductImporter(importService *ProductImportService, interval time.Duration, log logger.Logger) *ProductImporter {
+	return &ProductImporter{
+		importService: importService,
+		interval:      interval,
+		log:           log.With("component", "product_importer"),
+	}
+}
+
+// Run polls for jobs on every tick until ctx is cancelled
+func (i *ProductImporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			i.drain(ctx)
+		}
+	}
+}
+
+// drain runs jobs one after another until none are left
+func (i *ProductImporter) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		found, err := i.importService.ProcessNextJob(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				i.log.Error("Failed to run product import job", "error", err)
+			}
+			return
+		}
+		if !found {
+			return
+		}
+	}
+}
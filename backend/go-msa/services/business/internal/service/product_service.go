@@ -2,25 +2,32 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"business-service/internal/db"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type ProductService struct {
-	queries *db.Queries
+	conn      *db.Connection
+	queries   *db.Queries
+	chunkSize int
 }
 
-func NewProductService(queries *db.Queries) *ProductService {
+// NewProductService creates a ProductService; bulk operations write at most chunkSize items per statement batch
+func NewProductService(conn *db.Connection, chunkSize int) *ProductService {
 	return &ProductService{
-		queries: queries,
+		conn:      conn,
+		queries:   conn.Queries,
+		chunkSize: chunkSize,
 	}
 }
 
-// CreateProduct creates a new product
-func (s *ProductService) CreateProduct(ctx context.Context, name, description string, price float64, stock int32) (*db.Product, error) {
+// CreateProduct creates a new product and records its initial stock in the ledger
+func (s *ProductService) CreateProduct(ctx context.Context, actor, name, description string, price float64, stock int32) (*db.Product, error) {
 	params := db.CreateProductParams{
 		Name:        name,
 		Description: pgtype.Text{String: description, Valid: description != ""},
@@ -33,9 +40,25 @@ func (s *ProductService) CreateProduct(ctx context.Context, name, description st
 		return nil, fmt.Errorf("failed to convert price: %w", err)
 	}
 
-	product, err := s.queries.CreateProduct(ctx, params)
+	var product *db.Product
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		product, err = q.CreateProduct(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to create product: %w", err)
+		}
+
+		if err := enqueueEvent(ctx, q, product.ID, EventProductCreated, newProductEvent(product)); err != nil {
+			return err
+		}
+
+		return recordStockChange(ctx, q, product, product.Stock, Movement{
+			Reason: MovementReasonInitial,
+			Actor:  actor,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create product: %w", err)
+		return nil, err
 	}
 
 	return product, nil
@@ -55,12 +78,22 @@ func (s *ProductService) GetProduct(ctx context.Context, id uuid.UUID) (*db.Prod
 func (s *ProductService) GetProductByStringID(ctx context.Context, idStr string) (*db.Product, error) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid product ID: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProductID, err)
 	}
 
 	return s.GetProduct(ctx, id)
 }
 
+// GetProducts retrieves the products with the given IDs; IDs that do not exist are left out
+func (s *ProductService) GetProducts(ctx context.Context, ids []uuid.UUID) ([]*db.Product, error) {
+	products, err := s.queries.GetProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	return products, nil
+}
+
 // ListProducts retrieves all products
 func (s *ProductService) ListProducts(ctx context.Context) ([]*db.Product, error) {
 	products, err := s.queries.ListProducts(ctx)
@@ -71,8 +104,18 @@ func (s *ProductService) ListProducts(ctx context.Context) ([]*db.Product, error
 	return products, nil
 }
 
-// UpdateProduct updates an existing product
-func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, name, description string, price float64, stock int32) (*db.Product, error) {
+// ExportProducts passes every product with its external SKU to fn as it is read, without collecting them in memory.
+// fn must not retain the row; an error from fn stops the export.
+func (s *ProductService) ExportProducts(ctx context.Context, fn func(row *db.ProductExportRow) error) error {
+	if err := s.queries.StreamProducts(ctx, fn); err != nil {
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProduct updates an existing product, recording any stock difference in the ledger
+func (s *ProductService) UpdateProduct(ctx context.Context, actor string, id uuid.UUID, name, description string, price float64, stock int32) (*db.Product, error) {
 	params := db.UpdateProductParams{
 		ID:          id,
 		Name:        name,
@@ -85,38 +128,41 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, name,
 		return nil, fmt.Errorf("failed to convert price: %w", err)
 	}
 
-	product, err := s.queries.UpdateProduct(ctx, params)
+	var product *db.Product
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		product, err = updateProduct(ctx, q, actor, params)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update product: %w", err)
+		return nil, err
 	}
 
 	return product, nil
 }
 
 // UpdateProductByStringID updates a product by string ID (converts to UUID)
-func (s *ProductService) UpdateProductByStringID(ctx context.Context, idStr, name, description string, price float64, stock int32) (*db.Product, error) {
+func (s *ProductService) UpdateProductByStringID(ctx context.Context, actor, idStr, name, description string, price float64, stock int32) (*db.Product, error) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid product ID: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProductID, err)
 	}
 
-	return s.UpdateProduct(ctx, id, name, description, price, stock)
+	return s.UpdateProduct(ctx, actor, id, name, description, price, stock)
 }
 
-// DeleteProduct deletes a product by ID
+// DeleteProduct deletes a product by ID and announces the deletion through the outbox
 func (s *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
-	if err := s.queries.DeleteProduct(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete product: %w", err)
-	}
-
-	return nil
+	return s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		return deleteProduct(ctx, q, id)
+	})
 }
 
 // DeleteProductByStringID deletes a product by string ID (converts to UUID)
 func (s *ProductService) DeleteProductByStringID(ctx context.Context, idStr string) error {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return fmt.Errorf("invalid product ID: %w", err)
+		return fmt.Errorf("%w: %v", ErrInvalidProductID, err)
 	}
 
 	return s.DeleteProduct(ctx, id)
@@ -147,16 +193,85 @@ func (s *ProductService) GetProductsByPriceRange(ctx context.Context, minPrice,
 	return products, nil
 }
 
-// UpdateProductStock updates only the stock of a product
-func (s *ProductService) UpdateProductStock(ctx context.Context, id uuid.UUID, stock int32) (*db.Product, error) {
+// UpdateProductStock updates only the stock of a product, recording the difference in the ledger
+func (s *ProductService) UpdateProductStock(ctx context.Context, actor string, id uuid.UUID, stock int32) (*db.Product, error) {
 	params := db.UpdateProductStockParams{
 		ID:    id,
 		Stock: stock,
 	}
 
-	product, err := s.queries.UpdateProductStock(ctx, params)
+	var product *db.Product
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		current, err := lockProduct(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		product, err = q.UpdateProductStock(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to update product stock: %w", err)
+		}
+
+		return recordStockChange(ctx, q, product, product.Stock-current.Stock, Movement{
+			Reason: MovementReasonAdjustment,
+			Actor:  actor,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update product stock: %w", err)
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// updateProduct overwrites a product, recording any stock difference in the ledger; q must be bound to a transaction
+func updateProduct(ctx context.Context, q *db.Queries, actor string, params db.UpdateProductParams) (*db.Product, error) {
+	current, err := lockProduct(ctx, q, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := q.UpdateProduct(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	if err := enqueueEvent(ctx, q, product.ID, EventProductUpdated, newProductEvent(product)); err != nil {
+		return nil, err
+	}
+
+	err = recordStockChange(ctx, q, product, product.Stock-current.Stock, Movement{
+		Reason: MovementReasonProductUpdate,
+		Actor:  actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// deleteProduct deletes a product and announces the deletion; q must be bound to a transaction
+func deleteProduct(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	if _, err := lockProduct(ctx, q, id); err != nil {
+		return err
+	}
+
+	if err := q.DeleteProduct(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	return enqueueEvent(ctx, q, id, EventProductDeleted, ProductDeletedEvent{ID: id})
+}
+
+// lockProduct reads a product with a row lock so its stock can be diffed safely
+func lockProduct(ctx context.Context, q *db.Queries, id uuid.UUID) (*db.Product, error) {
+	product, err := q.GetProductForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
 
 	return product, nil
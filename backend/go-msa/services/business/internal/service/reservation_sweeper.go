@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"pkg/logger"
+)
+
+// ReservationSweeper periodically reclaims stock held by expired reservations
+type ReservationSweeper struct {
+	stockService *StockService
+	interval     time.Duration
+	batchSize    int32
+	log          logger.Logger
+}
+
+func NewReservationSweeper(stockService *StockService, interval time.Duration, batchSize int, log logger.Logger) *ReservationSweeper {
+	return &ReservationSweeper{
+		stockService: stockService,
+		interval:     interval,
+		batchSize:    int32(batchSize),
+		log:          log.With("component", "reservation_sweeper"),
+	}
+}
+
+// Run sweeps on every tick until ctx is cancelled
+func (s *ReservationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+// sweep drains expired reservations batch by batch
+func (s *ReservationSweeper) sweep(ctx context.Context) {
+	for ctx.Err() == nil {
+		count, err := s.stockService.ExpireReservations(ctx, s.batchSize)
+		if err != nil {
+			s.log.Error("Failed to expire stock reservations", "error", err)
+			return
+		}
+		if count > 0 {
+			s.log.Info("Expired stock reservations", "count", count)
+		}
+		if count < int(s.batchSize) {
+			return
+		}
+	}
+}
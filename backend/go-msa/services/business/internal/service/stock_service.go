@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"business-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Reservation statuses stored in stock_reservations.status
+const (
+	ReservationStatusPending   = "pending"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusReleased  = "released"
+	ReservationStatusExpired   = "expired"
+)
+
+type StockService struct {
+	conn       *db.Connection
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+}
+
+func NewStockService(conn *db.Connection, defaultTTL, maxTTL time.Duration) *StockService {
+	return &StockService{
+		conn:       conn,
+		defaultTTL: defaultTTL,
+		maxTTL:     maxTTL,
+	}
+}
+
+// AdjustStock applies a relative delta to a product's stock, refusing to go below zero
+func (s *StockService) AdjustStock(ctx context.Context, id uuid.UUID, delta int32, movement Movement) (*db.Product, error) {
+	if movement.Reason == "" {
+		movement.Reason = MovementReasonAdjustment
+	}
+	if !manualReasons[movement.Reason] {
+		return nil, ErrInvalidMovementReason
+	}
+
+	var product *db.Product
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		product, err = adjustStock(ctx, q, id, delta, movement)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// ReserveStock holds quantity units of a product until the reservation is confirmed, released or expires
+func (s *StockService) ReserveStock(ctx context.Context, productID uuid.UUID, quantity int32, ttl time.Duration, actor string) (*db.StockReservation, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	if ttl == 0 {
+		ttl = s.defaultTTL
+	}
+	if ttl < time.Second || ttl > s.maxTTL {
+		return nil, ErrInvalidReservationTTL
+	}
+
+	var reservation *db.StockReservation
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		product, err := q.AdjustProductStock(ctx, db.AdjustProductStockParams{Delta: -quantity, ID: productID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return stockShortageError(ctx, q, productID)
+			}
+			return fmt.Errorf("failed to adjust product stock: %w", err)
+		}
+
+		reservation, err = q.CreateStockReservation(ctx, db.CreateStockReservationParams{
+			ProductID:  productID,
+			Quantity:   quantity,
+			TtlSeconds: int32(ttl / time.Second),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create reservation: %w", err)
+		}
+
+		// The movement references the reservation, so it is recorded once the ID is known
+		return recordStockChange(ctx, q, product, -quantity, Movement{
+			Reason:      MovementReasonReservation,
+			Actor:       actor,
+			ReferenceID: reservation.ID.String(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// GetReservation retrieves a reservation by ID
+func (s *StockService) GetReservation(ctx context.Context, id uuid.UUID) (*db.StockReservation, error) {
+	reservation, err := s.conn.Queries.GetStockReservation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// ConfirmReservation turns a pending reservation into a permanent stock deduction
+func (s *StockService) ConfirmReservation(ctx context.Context, id uuid.UUID) (*db.StockReservation, error) {
+	reservation, err := s.conn.Queries.ConfirmStockReservation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.reservationStateError(ctx, s.conn.Queries, id)
+		}
+		return nil, fmt.Errorf("failed to confirm reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// ReleaseReservation cancels a pending reservation and returns its quantity to stock
+func (s *StockService) ReleaseReservation(ctx context.Context, id uuid.UUID, actor string) (*db.StockReservation, error) {
+	var reservation *db.StockReservation
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		reservation, err = q.ReleaseStockReservation(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return s.reservationStateError(ctx, q, id)
+			}
+			return fmt.Errorf("failed to release reservation: %w", err)
+		}
+
+		_, err = adjustStock(ctx, q, reservation.ProductID, reservation.Quantity, Movement{
+			Reason:      MovementReasonReservationRelease,
+			Actor:       actor,
+			ReferenceID: reservation.ID.String(),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ExpireReservations marks up to limit overdue reservations as expired and restocks them.
+// It returns the number of reservations reclaimed.
+func (s *StockService) ExpireReservations(ctx context.Context, limit int32) (int, error) {
+	var expired []*db.StockReservation
+	err := s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		expired, err = q.ExpireStockReservations(ctx, limit)
+		if err != nil {
+			return fmt.Errorf("failed to expire reservations: %w", err)
+		}
+
+		for _, reservation := range expired {
+			movement := Movement{
+				Reason:      MovementReasonReservationExpiry,
+				Actor:       SystemActor,
+				ReferenceID: reservation.ID.String(),
+			}
+			if _, err := adjustStock(ctx, q, reservation.ProductID, reservation.Quantity, movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(expired), nil
+}
+
+// reservationStateError explains why a reservation could not change state
+func (s *StockService) reservationStateError(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	if _, err := q.GetStockReservation(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReservationNotFound
+		}
+		return fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	return ErrReservationNotPending
+}
+
+// adjustStock applies delta atomically, records it in the ledger and enqueues a stock event; q must be bound to a transaction
+func adjustStock(ctx context.Context, q *db.Queries, id uuid.UUID, delta int32, movement Movement) (*db.Product, error) {
+	product, err := q.AdjustProductStock(ctx, db.AdjustProductStockParams{
+		Delta: delta,
+		ID:    id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, stockShortageError(ctx, q, id)
+		}
+		return nil, fmt.Errorf("failed to adjust product stock: %w", err)
+	}
+
+	if err := recordStockChange(ctx, q, product, delta, movement); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// stockShortageError distinguishes a missing product from insufficient stock after a guarded update matched nothing
+func stockShortageError(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	if _, err := q.GetProduct(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		return fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return ErrInsufficientStock
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"business-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testDatabaseURLEnv names a Postgres database the service tests may create schemas in; without it they are skipped
+const testDatabaseURLEnv = "BUSINESS_TEST_DATABASE_URL"
+
+// newTestConnection connects to a fresh schema built from db/schema.sql and drops it when the test ends
+func newTestConnection(t *testing.T) *db.Connection {
+	t.Helper()
+	url := os.Getenv(testDatabaseURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseURLEnv)
+	}
+	schemaSQL, err := os.ReadFile("../../db/schema.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	schema := "test_" + uuid.NewString()[:8]
+	admin, err := pgx.Connect(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer admin.Close(ctx)
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if conn, err := pgx.Connect(context.Background(), url); err == nil {
+			_, _ = conn.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+			conn.Close(context.Background())
+		}
+	})
+
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	poolConfig.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	if _, err := pool.Exec(ctx, string(schemaSQL)); err != nil {
+		t.Fatalf("failed to apply schema: %v", err)
+	}
+
+	return &db.Connection{Pool: pool, Queries: db.New(pool)}
+}
+
+func createTestProduct(t *testing.T, conn *db.Connection, stock int32) uuid.UUID {
+	t.Helper()
+	id := uuid.New()
+	_, err := conn.Pool.Exec(context.Background(),
+		"INSERT INTO products (id, name, price, stock) VALUES ($1, 'Widget', 10, $2)", id, stock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func productStock(t *testing.T, conn *db.Connection, id uuid.UUID) int32 {
+	t.Helper()
+	product, err := conn.Queries.GetProduct(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return product.Stock
+}
+
+func TestStockServiceNeverGoesNegative(t *testing.T) {
+	conn := newTestConnection(t)
+	stock := NewStockService(conn, time.Minute, time.Hour)
+	ctx := context.Background()
+	id := createTestProduct(t, conn, 5)
+
+	if _, err := stock.AdjustStock(ctx, id, -6, Movement{Actor: "user-1"}); !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("AdjustStock(-6) error = %v, want ErrInsufficientStock", err)
+	}
+	if _, err := stock.ReserveStock(ctx, id, 6, 0, "user-1"); !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("ReserveStock(6) error = %v, want ErrInsufficientStock", err)
+	}
+	if got := productStock(t, conn, id); got != 5 {
+		t.Errorf("stock = %d after rejected changes, want 5", got)
+	}
+
+	if product, err := stock.AdjustStock(ctx, id, -5, Movement{Actor: "user-1"}); err != nil || product.Stock != 0 {
+		t.Errorf("AdjustStock(-5) = %v, %v, want stock 0", product, err)
+	}
+	if _, err := stock.AdjustStock(ctx, uuid.New(), -1, Movement{}); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("AdjustStock(unknown) error = %v, want ErrProductNotFound", err)
+	}
+}
+
+func TestStockServiceSettledReservations(t *testing.T) {
+	conn := newTestConnection(t)
+	stock := NewStockService(conn, time.Minute, time.Hour)
+	ctx := context.Background()
+	id := createTestProduct(t, conn, 10)
+
+	reserve := func() *db.StockReservation {
+		t.Helper()
+		reservation, err := stock.ReserveStock(ctx, id, 2, 0, "user-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reservation
+	}
+
+	confirmed := reserve()
+	if _, err := stock.ConfirmReservation(ctx, confirmed.ID); err != nil {
+		t.Fatal(err)
+	}
+	released := reserve()
+	if _, err := stock.ReleaseReservation(ctx, released.ID, "user-1"); err != nil {
+		t.Fatal(err)
+	}
+	expired := reserve()
+	if _, err := conn.Pool.Exec(ctx, "UPDATE stock_reservations SET expires_at = NOW() - INTERVAL '1 second' WHERE id = $1", expired.ID); err != nil {
+		t.Fatal(err)
+	}
+	// 10 - 2 confirmed - 2 expired but not yet swept
+	const wantStock = 6
+
+	for name, reservation := range map[string]*db.StockReservation{"confirmed": confirmed, "released": released, "expired": expired} {
+		if _, err := stock.ConfirmReservation(ctx, reservation.ID); !errors.Is(err, ErrReservationNotPending) {
+			t.Errorf("confirm %s: error = %v, want ErrReservationNotPending", name, err)
+		}
+		if name == "expired" {
+			// A lapsed reservation the sweeper has not reached yet may still be released
+			continue
+		}
+		if _, err := stock.ReleaseReservation(ctx, reservation.ID, "user-1"); !errors.Is(err, ErrReservationNotPending) {
+			t.Errorf("release %s: error = %v, want ErrReservationNotPending", name, err)
+		}
+	}
+	if got := productStock(t, conn, id); got != wantStock {
+		t.Errorf("stock = %d, want %d: settled reservations must not be restocked twice", got, wantStock)
+	}
+
+	if count, err := stock.ExpireReservations(ctx, 10); err != nil || count != 1 {
+		t.Errorf("ExpireReservations() = %d, %v, want 1", count, err)
+	}
+	if _, err := stock.ReleaseReservation(ctx, expired.ID, "user-1"); !errors.Is(err, ErrReservationNotPending) {
+		t.Errorf("release after expiry: error = %v, want ErrReservationNotPending", err)
+	}
+	if got := productStock(t, conn, id); got != wantStock+2 {
+		t.Errorf("stock = %d after expiry, want %d", got, wantStock+2)
+	}
+
+	if _, err := stock.ConfirmReservation(ctx, uuid.New()); !errors.Is(err, ErrReservationNotFound) {
+		t.Errorf("confirm unknown: error = %v, want ErrReservationNotFound", err)
+	}
+}
+
+func TestStockServiceRejectsInvalidReservations(t *testing.T) {
+	// Arguments are checked before the database is touched
+	stock := NewStockService(nil, time.Minute, time.Hour)
+	ctx := context.Background()
+
+	tests := []struct {
+		quantity int32
+		ttl      time.Duration
+		want     error
+	}{
+		{quantity: 0, want: ErrInvalidQuantity},
+		{quantity: -1, want: ErrInvalidQuantity},
+		{quantity: 1, ttl: time.Millisecond, want: ErrInvalidReservationTTL},
+		{quantity: 1, ttl: 2 * time.Hour, want: ErrInvalidReservationTTL},
+	}
+	for _, tt := range tests {
+		if _, err := stock.ReserveStock(ctx, uuid.New(), tt.quantity, tt.ttl, "user-1"); !errors.Is(err, tt.want) {
+			t.Errorf("ReserveStock(%d, %v) error = %v, want %v", tt.quantity, tt.ttl, err, tt.want)
+		}
+	}
+	if _, err := stock.AdjustStock(ctx, uuid.New(), 1, Movement{Reason: MovementReasonReservation}); !errors.Is(err, ErrInvalidMovementReason) {
+		t.Errorf("AdjustStock with a system reason error = %v, want ErrInvalidMovementReason", err)
+	}
+}
+
+// rowsDBTX answers QueryRow with queued rows, so single-query paths run without Postgres
+type rowsDBTX struct {
+	rows []pgx.Row
+}
+
+func (d *rowsDBTX) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, fmt.Errorf("unexpected Exec")
+}
+
+func (d *rowsDBTX) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, fmt.Errorf("unexpected Query")
+}
+
+func (d *rowsDBTX) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	row := d.rows[0]
+	d.rows = d.rows[1:]
+	return row
+}
+
+// errRow is a row that fails to scan with err, or scans nothing when err is nil
+type errRow struct{ err error }
+
+func (r errRow) Scan(...interface{}) error { return r.err }
+
+func TestConfirmReservationExplainsRejection(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []pgx.Row
+		want error
+	}{
+		// The guarded update matched nothing, but the reservation exists
+		{name: "settled", rows: []pgx.Row{errRow{pgx.ErrNoRows}, errRow{}}, want: ErrReservationNotPending},
+		{name: "missing", rows: []pgx.Row{errRow{pgx.ErrNoRows}, errRow{pgx.ErrNoRows}}, want: ErrReservationNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stock := NewStockService(&db.Connection{Queries: db.New(&rowsDBTX{rows: tt.rows})}, time.Minute, time.Hour)
+			if _, err := stock.ConfirmReservation(context.Background(), uuid.New()); !errors.Is(err, tt.want) {
+				t.Errorf("ConfirmReservation() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+CREATE TABLE orders
+(
+    id           UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    user_id      VARCHAR(255)             NOT NULL,
+    status       VARCHAR(20)              NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'paid', 'shipped', 'cancelled')),
+    total_amount DECIMAL(12, 2)           NOT NULL CHECK (total_amount >= 0),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_orders_user_id_created_at ON orders (user_id, created_at);
+
+-- Line items snapshot the product name and price at the time the order was placed
+CREATE TABLE order_items
+(
+    id           UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    order_id     UUID                     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    product_id   UUID                     NOT NULL,
+    product_name VARCHAR(255)             NOT NULL,
+    unit_price   DECIMAL(10, 2)           NOT NULL CHECK (unit_price >= 0),
+    quantity     INTEGER                  NOT NULL CHECK (quantity > 0),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_order_items_order_id ON order_items (order_id);
@@ -0,0 +1,4 @@
+-- Stock business-service holds for a line item until the order is paid or cancelled.
+-- Items of the same product share a reservation; orders placed before reservations have none.
+ALTER TABLE order_items
+    ADD COLUMN reservation_id UUID;
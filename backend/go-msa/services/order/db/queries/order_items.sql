@@ -0,0 +1,10 @@
+-- name: CreateOrderItem :one
+INSERT INTO order_items (order_id, product_id, product_name, unit_price, quantity, reservation_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, order_id, product_id, product_name, unit_price, quantity, created_at, reservation_id;
+
+-- name: ListOrderItems :many
+SELECT id, order_id, product_id, product_name, unit_price, quantity, created_at, reservation_id
+FROM order_items
+WHERE order_id = $1
+ORDER BY created_at, id;
@@ -0,0 +1,22 @@
+-- name: CreateOrder :one
+INSERT INTO orders (user_id, total_amount)
+VALUES ($1, $2)
+RETURNING id, user_id, status, total_amount, created_at, updated_at;
+
+-- name: GetOrder :one
+SELECT id, user_id, status, total_amount, created_at, updated_at
+FROM orders
+WHERE id = $1;
+
+-- name: ListOrdersByUser :many
+SELECT id, user_id, status, total_amount, created_at, updated_at
+FROM orders
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateOrderStatus :one
+UPDATE orders
+SET status = sqlc.arg(status), updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING id, user_id, status, total_amount, created_at, updated_at;
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	businessv1 "pkg/proto/business/v1"
+	"pkg/rpc"
+)
+
+// GRPCProductClient calls business-service's business.v1.ProductService
+type GRPCProductClient struct {
+	conn    *grpc.ClientConn
+	client  businessv1.ProductServiceClient
+	timeout time.Duration
+}
+
+func NewGRPCProductClient(businessServiceAddr string, timeout time.Duration) (*GRPCProductClient, error) {
+	conn, err := rpc.Dial(businessServiceAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business service address: %w", err)
+	}
+
+	return &GRPCProductClient{
+		conn:    conn,
+		client:  businessv1.NewProductServiceClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Close releases the connection to business-service
+func (c *GRPCProductClient) Close() error {
+	return c.conn.Close()
+}
+
+// GetProducts fetches products in one BatchGetProducts call
+func (c *GRPCProductClient) GetProducts(ctx context.Context, userID string, ids []uuid.UUID) (map[uuid.UUID]*Product, error) {
+	ctx, cancel := c.callContext(ctx, userID)
+	defer cancel()
+
+	req := &businessv1.BatchGetProductsRequest{Ids: make([]string, len(ids))}
+	for i, id := range ids {
+		req.Ids[i] = id.String()
+	}
+
+	resp, err := c.client.BatchGetProducts(ctx, req)
+	if err != nil {
+		return nil, callError(err)
+	}
+
+	products := make(map[uuid.UUID]*Product, len(resp.GetProducts()))
+	for _, p := range resp.GetProducts() {
+		id, err := uuid.Parse(p.GetId())
+		if err != nil {
+			return nil, fmt.Errorf("business service returned invalid product ID %q: %w", p.GetId(), err)
+		}
+		products[id] = &Product{
+			ID:    p.GetId(),
+			Name:  p.GetName(),
+			Price: p.GetPrice(),
+			Stock: p.GetStock(),
+		}
+	}
+
+	return products, nil
+}
+
+func (c *GRPCProductClient) ReserveStock(ctx context.Context, userID string, productID uuid.UUID, quantity int32, ttl time.Duration) (*Reservation, error) {
+	ctx, cancel := c.callContext(ctx, userID)
+	defer cancel()
+
+	reservation, err := c.client.ReserveStock(ctx, &businessv1.ReserveStockRequest{
+		ProductId:  productID.String(),
+		Quantity:   quantity,
+		TtlSeconds: int32(ttl / time.Second),
+	})
+	return convertReservation(reservation, err)
+}
+
+func (c *GRPCProductClient) GetReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error) {
+	ctx, cancel := c.callContext(ctx, userID)
+	defer cancel()
+
+	reservation, err := c.client.GetReservation(ctx, &businessv1.GetReservationRequest{Id: id.String()})
+	return convertReservation(reservation, err)
+}
+
+func (c *GRPCProductClient) ConfirmReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error) {
+	ctx, cancel := c.callContext(ctx, userID)
+	defer cancel()
+
+	reservation, err := c.client.ConfirmReservation(ctx, &businessv1.ConfirmReservationRequest{Id: id.String()})
+	return convertReservation(reservation, err)
+}
+
+func (c *GRPCProductClient) ReleaseReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error) {
+	ctx, cancel := c.callContext(ctx, userID)
+	defer cancel()
+
+	reservation, err := c.client.ReleaseReservation(ctx, &businessv1.ReleaseReservationRequest{Id: id.String()})
+	return convertReservation(reservation, err)
+}
+
+// callContext bounds a call by the client timeout and marks it as made on behalf of userID
+func (c *GRPCProductClient) callContext(ctx context.Context, userID string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(rpc.WithUserID(ctx, userID), c.timeout)
+}
+
+// callError returns the sentinel error for a known business-service error code
+func callError(err error) error {
+	if known, ok := codeErrors[rpc.Reason(err)]; ok {
+		return known
+	}
+	return fmt.Errorf("failed to call business service: %w", err)
+}
+
+// convertReservation converts a reservation message, passing call errors through callError
+func convertReservation(reservation *businessv1.Reservation, err error) (*Reservation, error) {
+	if err != nil {
+		return nil, callError(err)
+	}
+
+	id, err := uuid.Parse(reservation.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("business service returned invalid reservation ID %q: %w", reservation.GetId(), err)
+	}
+	productID, err := uuid.Parse(reservation.GetProductId())
+	if err != nil {
+		return nil, fmt.Errorf("business service returned invalid product ID %q: %w", reservation.GetProductId(), err)
+	}
+
+	return &Reservation{
+		ID:        id,
+		ProductID: productID,
+		Quantity:  reservation.GetQuantity(),
+		Status:    reservation.GetStatus(),
+	}, nil
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"pkg/logger"
+	"pkg/middleware"
+	"pkg/models"
+	"pkg/router"
+	"pkg/telemetry"
+)
+
+// reserveStockRequest is the body of POST /products/{id}/stock/reservations
+type reserveStockRequest struct {
+	Quantity   int32 `json:"quantity"`
+	TTLSeconds int32 `json:"ttl_seconds,omitempty"`
+}
+
+// HTTPProductClient calls business-service's REST API
+type HTTPProductClient struct {
+	baseURL     *url.URL
+	pathBuilder *router.PathBuilder
+	httpClient  *http.Client
+}
+
+func NewHTTPProductClient(businessServiceURL string, timeout time.Duration) (*HTTPProductClient, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(businessServiceURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid business service URL: %w", err)
+	}
+
+	return &HTTPProductClient{
+		baseURL:     baseURL,
+		pathBuilder: router.NewPathBuilder("v1", "business"),
+		httpClient:  &http.Client{Timeout: timeout, Transport: telemetry.Transport(nil)},
+	}, nil
+}
+
+// Close releases idle connections to business-service
+func (c *HTTPProductClient) Close() error {
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// GetProducts fetches each product in turn, since the REST API has no batch lookup
+func (c *HTTPProductClient) GetProducts(ctx context.Context, userID string, ids []uuid.UUID) (map[uuid.UUID]*Product, error) {
+	products := make(map[uuid.UUID]*Product, len(ids))
+	for _, id := range ids {
+		var product Product
+		err := c.do(ctx, userID, http.MethodGet, nil, &product, "products", id.String())
+		if err != nil {
+			if errors.Is(err, ErrProductNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		products[id] = &product
+	}
+
+	return products, nil
+}
+
+func (c *HTTPProductClient) ReserveStock(ctx context.Context, userID string, productID uuid.UUID, quantity int32, ttl time.Duration) (*Reservation, error) {
+	body := reserveStockRequest{Quantity: quantity, TTLSeconds: int32(ttl / time.Second)}
+	var reservation Reservation
+	if err := c.do(ctx, userID, http.MethodPost, body, &reservation, "products", productID.String(), "stock", "reservations"); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (c *HTTPProductClient) GetReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error) {
+	var reservation Reservation
+	if err := c.do(ctx, userID, http.MethodGet, nil, &reservation, "reservations", id.String()); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (c *HTTPProductClient) ConfirmReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error) {
+	var reservation Reservation
+	if err := c.do(ctx, userID, http.MethodPost, nil, &reservation, "reservations", id.String(), "confirm"); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (c *HTTPProductClient) ReleaseReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error) {
+	var reservation Reservation
+	if err := c.do(ctx, userID, http.MethodPost, nil, &reservation, "reservations", id.String(), "release"); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// do sends body as JSON to the path built from segments and decodes a successful response into out.
+// Error responses carrying a known code are returned as the matching sentinel error.
+func (c *HTTPProductClient) do(ctx context.Context, userID, method string, body, out any, segments ...string) error {
+	endpoint := c.baseURL.JoinPath(c.pathBuilder.Path(segments...))
+
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-Authenticated-User-ID", userID)
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		req.Header.Set(middleware.RequestIDHeader, requestID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call business service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return responseError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode business service response: %w", err)
+	}
+	return nil
+}
+
+// responseError converts an error response.
+// A product lookup answers 404 without a specific code, so any other 404 is treated as a missing product.
+func responseError(resp *http.Response) error {
+	var body models.APIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Error != nil {
+		if err, ok := codeErrors[body.Error.Code]; ok {
+			return err
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrProductNotFound
+	}
+	return fmt.Errorf("business service returned status %d", resp.StatusCode)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"order-service/internal/config"
+	"pkg/models"
+)
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(models.NewErrorResponse(code, "error", ""))
+}
+
+func TestHTTPProductClient(t *testing.T) {
+	known, missing, scarce := uuid.New(), uuid.New(), uuid.New()
+	reservationID := uuid.New()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/business/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != known.String() {
+			// Product lookups answer 404 with the generic code
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Product{ID: known.String(), Name: "Widget", Price: 9.5, Stock: 3})
+	})
+	mux.HandleFunc("POST /v1/business/products/{id}/stock/reservations", func(w http.ResponseWriter, r *http.Request) {
+		var req reserveStockRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || r.Header.Get("X-Authenticated-User-ID") != "user-1" {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST")
+			return
+		}
+		if r.PathValue("id") == scarce.String() {
+			writeError(w, http.StatusConflict, "INSUFFICIENT_STOCK")
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(Reservation{ID: reservationID, ProductID: known, Quantity: req.Quantity, Status: "pending"})
+	})
+	mux.HandleFunc("POST /v1/business/reservations/{id}/confirm", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusConflict, "RESERVATION_NOT_PENDING")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, err := NewHTTPProductClient(server.URL+"/", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	products, err := c.GetProducts(ctx, "user-1", []uuid.UUID{known, missing})
+	if err != nil {
+		t.Fatalf("GetProducts() error = %v", err)
+	}
+	if len(products) != 1 || products[known].Name != "Widget" {
+		t.Errorf("GetProducts() = %v, want only the existing product", products)
+	}
+
+	reservation, err := c.ReserveStock(ctx, "user-1", known, 2, time.Minute)
+	if err != nil || reservation.ID != reservationID || reservation.Quantity != 2 {
+		t.Errorf("ReserveStock() = %+v, %v", reservation, err)
+	}
+	if _, err := c.ReserveStock(ctx, "user-1", scarce, 2, time.Minute); !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("ReserveStock() error = %v, want ErrInsufficientStock", err)
+	}
+	if _, err := c.ConfirmReservation(ctx, "user-1", reservationID); !errors.Is(err, ErrReservationNotPending) {
+		t.Errorf("ConfirmReservation() error = %v, want ErrReservationNotPending", err)
+	}
+}
+
+func TestNewProductClient(t *testing.T) {
+	cfg := config.ServicesConfig{
+		BusinessServiceURL:      "http://localhost:8082",
+		BusinessServiceGRPCAddr: "localhost:9090",
+		RequestTimeout:          time.Second,
+	}
+
+	for transport, want := range map[string]string{"http": "*client.HTTPProductClient", "grpc": "*client.GRPCProductClient"} {
+		cfg.BusinessServiceTransport = transport
+		c, err := NewProductClient(cfg)
+		if err != nil {
+			t.Fatalf("NewProductClient(%s) error = %v", transport, err)
+		}
+		if got := fmt.Sprintf("%T", c); got != want {
+			t.Errorf("NewProductClient(%s) = %s, want %s", transport, got, want)
+		}
+		_ = c.Close()
+	}
+
+	cfg.BusinessServiceTransport = "smtp"
+	if _, err := NewProductClient(cfg); err == nil {
+		t.Error("unknown transport accepted")
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"order-service/internal/config"
+)
+
+var (
+	ErrProductNotFound       = errors.New("product not found")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrReservationNotFound   = errors.New("reservation not found")
+	ErrReservationNotPending = errors.New("reservation is not pending")
+)
+
+// codeErrors maps business-service error codes to the errors above, whichever transport carried them
+var codeErrors = map[string]error{
+	"PRODUCT_NOT_FOUND":       ErrProductNotFound,
+	"INSUFFICIENT_STOCK":      ErrInsufficientStock,
+	"RESERVATION_NOT_FOUND":   ErrReservationNotFound,
+	"RESERVATION_NOT_PENDING": ErrReservationNotPending,
+}
+
+// ReservationStatusConfirmed is the status of a reservation whose stock has been kept
+const ReservationStatusConfirmed = "confirmed"
+
+// Product is the subset of business-service's product representation the order service needs
+type Product struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+	Stock int32   `json:"stock"`
+}
+
+// Reservation is stock business-service holds for an order until it is confirmed, released or expires
+type Reservation struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int32     `json:"quantity"`
+	Status    string    `json:"status"`
+}
+
+// ProductClient reads products and reserves stock in business-service.
+// Every call is made on behalf of userID, which business-service requires for authorization.
+type ProductClient interface {
+	// GetProducts fetches products by ID. Products that do not exist are missing from the result.
+	GetProducts(ctx context.Context, userID string, ids []uuid.UUID) (map[uuid.UUID]*Product, error)
+	// ReserveStock holds quantity units of a product for ttl, failing with ErrInsufficientStock if they are not available
+	ReserveStock(ctx context.Context, userID string, productID uuid.UUID, quantity int32, ttl time.Duration) (*Reservation, error)
+	GetReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error)
+	// ConfirmReservation keeps a pending reservation's stock deducted
+	ConfirmReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error)
+	// ReleaseReservation returns a pending reservation's stock
+	ReleaseReservation(ctx context.Context, userID string, id uuid.UUID) (*Reservation, error)
+	Close() error
+}
+
+// NewProductClient creates the client for the transport selected by cfg.BusinessServiceTransport
+func NewProductClient(cfg config.ServicesConfig) (ProductClient, error) {
+	switch cfg.BusinessServiceTransport {
+	case "http":
+		return NewHTTPProductClient(cfg.BusinessServiceURL, cfg.RequestTimeout)
+	case "grpc":
+		return NewGRPCProductClient(cfg.BusinessServiceGRPCAddr, cfg.RequestTimeout)
+	default:
+		return nil, fmt.Errorf("unknown business service transport: %q", cfg.BusinessServiceTransport)
+	}
+}
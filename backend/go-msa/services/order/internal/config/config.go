@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"pkg/app"
+	"pkg/config"
+	"pkg/middleware"
+)
+
+// Config holds all configuration for the order service
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Secrets   SecretsConfig   `yaml:"secrets"`
+	Services  ServicesConfig  `yaml:"services"`
+	Order     OrderConfig     `yaml:"order"`
+	Telemetry TelemetryConfig `yaml:"telemetry"`
+	Log       LogConfig       `yaml:"log"`
+}
+
+type ServerConfig struct {
+	Port              int           `yaml:"port" env:"PORT" default:"8083"`
+	Host              string        `yaml:"host" env:"HOST" default:"0.0.0.0"`
+	ErrorFormat       string        `yaml:"error_format" env:"ERROR_FORMAT" default:"json"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT" default:"5s"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT" default:"15s"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT" default:"30s"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" default:"60s"`
+	DrainPeriod       time.Duration `yaml:"drain_period" env:"SERVER_DRAIN_PERIOD" default:"0s"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" default:"10s"`
+	RequestTimeout    time.Duration `yaml:"request_timeout" env:"SERVER_REQUEST_TIMEOUT" default:"30s"`
+	MaxBodyBytes      int64         `yaml:"max_body_bytes" env:"SERVER_MAX_BODY_BYTES" default:"10485760"`
+	TrustedProxies    []string      `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" env:"DB_HOST" default:"localhost"`
+	Port     int    `yaml:"port" env:"DB_PORT" default:"5432"`
+	User     string `yaml:"user" env:"DB_USER" default:"user"`
+	Password string `yaml:"password" env:"DB_PASSWORD" default:"password" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME" default:"db"`
+	SSLMode  string `yaml:"ssl_mode" env:"DB_SSLMODE" default:"disable"`
+}
+
+type ServicesConfig struct {
+	// BusinessServiceTransport selects how business-service is called: http or grpc
+	BusinessServiceTransport string        `yaml:"business_service_transport" env:"BUSINESS_SERVICE_TRANSPORT" default:"http"`
+	BusinessServiceURL       string        `yaml:"business_service_url" env:"BUSINESS_SERVICE_URL" default:"http://localhost:8082"`
+	BusinessServiceGRPCAddr  string        `yaml:"business_service_grpc_addr" env:"BUSINESS_SERVICE_GRPC_ADDR" default:"localhost:9090"`
+	RequestTimeout           time.Duration `yaml:"request_timeout" env:"SERVICE_REQUEST_TIMEOUT" default:"5s"`
+}
+
+type OrderConfig struct {
+	MaxItemsPerOrder int `yaml:"max_items_per_order" env:"MAX_ITEMS_PER_ORDER" default:"50"`
+	MaxOrdersPerPage int `yaml:"max_orders_per_page" env:"MAX_ORDERS_PER_PAGE" default:"50"`
+	// ReservationTTL is how long a pending order holds its stock before it can no longer be paid
+	ReservationTTL time.Duration `yaml:"reservation_ttl" env:"ORDER_RESERVATION_TTL" default:"30m"`
+}
+
+type SecretsConfig struct {
+	Provider        string        `yaml:"provider" env:"SECRETS_PROVIDER" default:"env"`
+	Dir             string        `yaml:"dir" env:"SECRETS_DIR" default:"/run/secrets"`
+	RefreshInterval time.Duration `yaml:"refresh_interval" env:"SECRETS_REFRESH_INTERVAL" default:"1m"`
+}
+
+type TelemetryConfig struct {
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"OTLP_ENDPOINT"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env:"OTLP_INSECURE" default:"true"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1.0"`
+}
+
+type LogConfig struct {
+	Level            string   `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Format           string   `yaml:"format" env:"LOG_FORMAT" default:"json"`
+	AccessSkipPaths  []string `yaml:"access_skip_paths" env:"ACCESS_LOG_SKIP_PATHS" default:"/health,/metrics"`
+	AccessSampleRate float64  `yaml:"access_sample_rate" env:"ACCESS_LOG_SAMPLE_RATE" default:"1"`
+}
+
+// AccessLog returns the access log settings for middleware.LoggingMiddleware
+func (c LogConfig) AccessLog() middleware.AccessLogConfig {
+	return middleware.AccessLogConfig{
+		SkipPaths:  c.AccessSkipPaths,
+		SampleRate: c.AccessSampleRate,
+	}
+}
+
+// AppConfig returns the HTTP server and shutdown settings for pkg/app
+func (c ServerConfig) AppConfig() app.Config {
+	return app.Config{
+		Addr:              net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		ReadTimeout:       c.ReadTimeout,
+		WriteTimeout:      c.WriteTimeout,
+		IdleTimeout:       c.IdleTimeout,
+		DrainPeriod:       c.DrainPeriod,
+		ShutdownTimeout:   c.ShutdownTimeout,
+	}
+}
+
+// LoadConfig loads configuration from defaults, an optional YAML file, environment variables and flags
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := config.Load(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Redacted returns the effective configuration with secrets masked, for logging at startup
+func (c *Config) Redacted() map[string]interface{} {
+	return config.Redacted(c)
+}
+
+// GetDatabaseURL returns formatted PostgreSQL connection string
+func (dCfg *DatabaseConfig) GetDatabaseURL() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		dCfg.User,
+		dCfg.Password,
+		dCfg.Host,
+		dCfg.Port,
+		dCfg.Name,
+		dCfg.SSLMode,
+	)
+}
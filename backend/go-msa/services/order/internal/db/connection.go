@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"order-service/internal/config"
+	pkgconfig "pkg/config"
+	"pkg/telemetry"
+)
+
+// SchemaVersion is the latest flyway migration this build depends on
+const SchemaVersion = 2
+
+// Connection wraps the database connection and queries
+type Connection struct {
+	Pool    *pgxpool.Pool
+	Queries *Queries
+}
+
+// NewConnection creates a new PostgreSQL connection using pgx
+// The password is read from the secret for every new connection, so a rotated
+// password applies to the pool without a restart.
+func NewConnection(cfg config.DatabaseConfig, password *pkgconfig.Secret) (*Connection, error) {
+	// Build connection string
+	connStr := fmt.Sprintf(
+		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		cfg.User,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port,
+		cfg.Name,
+		cfg.SSLMode,
+	)
+
+	poolConfig, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse connection string: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = telemetry.NewQueryTracer()
+	if password != nil {
+		poolConfig.BeforeConnect = func(_ context.Context, connConfig *pgx.ConnConfig) error {
+			connConfig.Password = password.Value()
+			return nil
+		}
+	}
+
+	// Create connection pool
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+
+	// Test the connection
+	if err := pool.Ping(context.Background()); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// Create queries instance
+	queries := New(pool)
+
+	return &Connection{
+		Pool:    pool,
+		Queries: queries,
+	}, nil
+}
+
+// Close closes the database connection
+func (c *Connection) Close() {
+	if c.Pool != nil {
+		c.Pool.Close()
+	}
+}
+
+// ExecTx runs fn inside a single transaction, committing on success and rolling back on error
+func (c *Connection) ExecTx(ctx context.Context, fn func(q *Queries) error) error {
+	tx, err := c.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(c.Queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
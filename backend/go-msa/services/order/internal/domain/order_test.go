@@ -0,0 +1,50 @@
+package domain
+
+import "testing"
+
+func TestOrderStatusCanTransitionTo(t *testing.T) {
+	statuses := []OrderStatus{OrderStatusPending, OrderStatusPaid, OrderStatusShipped, OrderStatusCancelled}
+	allowed := map[[2]OrderStatus]bool{
+		{OrderStatusPending, OrderStatusPaid}:      true,
+		{OrderStatusPending, OrderStatusCancelled}: true,
+		{OrderStatusPaid, OrderStatusShipped}:      true,
+		{OrderStatusPaid, OrderStatusCancelled}:    true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := allowed[[2]OrderStatus{from, to}]
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestOrderStatusUnknown(t *testing.T) {
+	unknown := OrderStatus("refunded")
+
+	if unknown.Valid() {
+		t.Error("Valid() = true for an unknown status")
+	}
+	if unknown.IsFinal() {
+		t.Error("IsFinal() = true for an unknown status")
+	}
+	if unknown.CanTransitionTo(OrderStatusPaid) || OrderStatusPending.CanTransitionTo(unknown) {
+		t.Error("CanTransitionTo() allowed a transition involving an unknown status")
+	}
+}
+
+func TestOrderStatusIsFinal(t *testing.T) {
+	tests := map[OrderStatus]bool{
+		OrderStatusPending:   false,
+		OrderStatusPaid:      false,
+		OrderStatusShipped:   true,
+		OrderStatusCancelled: true,
+	}
+	for status, want := range tests {
+		if got := status.IsFinal(); got != want {
+			t.Errorf("%s.IsFinal() = %v, want %v", status, got, want)
+		}
+	}
+}
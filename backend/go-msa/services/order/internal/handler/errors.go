@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"order-service/internal/service"
+	"pkg/common_handler"
+	apperrors "pkg/errors"
+	"pkg/logger"
+)
+
+// NewErrorHandler maps order-service domain errors to HTTP responses
+func NewErrorHandler(log logger.Logger) *common_handler.ErrorHandler {
+	return common_handler.NewErrorHandler(log).
+		Map(service.ErrEmptyOrder, http.StatusBadRequest, "EMPTY_ORDER").
+		Map(service.ErrTooManyItems, http.StatusBadRequest, "TOO_MANY_ITEMS").
+		Map(service.ErrInvalidQuantity, http.StatusBadRequest, "INVALID_QUANTITY").
+		Map(service.ErrOrderNotFound, http.StatusNotFound, "ORDER_NOT_FOUND").
+		Map(service.ErrProductNotFound, http.StatusUnprocessableEntity, "PRODUCT_NOT_FOUND").
+		Map(service.ErrInsufficientStock, http.StatusConflict, "INSUFFICIENT_STOCK").
+		Map(service.ErrInvalidTransition, http.StatusConflict, "INVALID_TRANSITION").
+		Map(service.ErrConcurrentUpdate, http.StatusConflict, "CONCURRENT_UPDATE").
+		Map(service.ErrReservationExpired, http.StatusConflict, "RESERVATION_EXPIRED")
+}
+
+// requireUserID returns the user the gateway authenticated
+func requireUserID(r *http.Request) (string, error) {
+	userID := r.Header.Get("X-Authenticated-User-ID")
+	if userID == "" {
+		return "", apperrors.NewUnauthorizedError("Unauthorized")
+	}
+	return userID, nil
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"pkg/openapi"
+	"pkg/router"
+)
+
+// NewOpenAPISpec documents every order-service route registered on v1
+func NewOpenAPISpec(title string, v1 *router.VersionedRoutes) *openapi.Spec {
+	spec := openapi.New(title, v1.Version())
+	tags := []string{"orders"}
+	transitionErrors := []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}
+
+	spec.Add(v1.Pattern("GET orders"), openapi.Endpoint{
+		Summary: "List the caller's orders, newest first",
+		Tags:    tags,
+		Query: []openapi.Parameter{
+			openapi.QueryParam("limit", "integer", "Page size", false),
+			openapi.QueryParam("offset", "integer", "Rows to skip", false),
+		},
+		Response: []OrderResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	})
+	spec.Add(v1.Pattern("POST orders"), openapi.Endpoint{
+		Summary:  "Place an order, reserving stock for each item",
+		Tags:     tags,
+		Request:  CreateOrderRequest{},
+		Response: OrderResponse{},
+		Status:   http.StatusCreated,
+		Errors:   []int{http.StatusUnprocessableEntity, http.StatusConflict},
+	})
+	spec.Add(v1.Pattern("GET orders/{id}"), openapi.Endpoint{
+		Summary:  "Get one of the caller's orders",
+		Tags:     tags,
+		Response: OrderResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	spec.Add(v1.Pattern("POST orders/{id}/pay"), openapi.Endpoint{
+		Summary:  "Mark an order as paid",
+		Tags:     tags,
+		Response: OrderResponse{},
+		Errors:   transitionErrors,
+	})
+	spec.Add(v1.Pattern("POST orders/{id}/ship"), openapi.Endpoint{
+		Summary:  "Mark a paid order as shipped",
+		Tags:     tags,
+		Response: OrderResponse{},
+		Errors:   transitionErrors,
+	})
+	spec.Add(v1.Pattern("POST orders/{id}/cancel"), openapi.Endpoint{
+		Summary:  "Cancel an order and release its stock",
+		Tags:     tags,
+		Response: OrderResponse{},
+		Errors:   transitionErrors,
+	})
+
+	return spec
+}
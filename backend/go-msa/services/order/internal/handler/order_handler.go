@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"order-service/internal/db"
+	"order-service/internal/domain"
+	"order-service/internal/service"
+	apperrors "pkg/errors"
+	"pkg/validation"
+)
+
+type OrderHandler struct {
+	orderService *service.OrderService
+	maxPageSize  int
+}
+
+func NewOrderHandler(orderService *service.OrderService, maxPageSize int) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+		maxPageSize:  maxPageSize,
+	}
+}
+
+type OrderItemRequest struct {
+	ProductID string `json:"product_id" validate:"required,uuid"`
+	Quantity  int32  `json:"quantity"`
+}
+
+type CreateOrderRequest struct {
+	Items []OrderItemRequest `json:"items"`
+}
+
+type OrderItemResponse struct {
+	ID          string  `json:"id"`
+	ProductID   string  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	UnitPrice   float64 `json:"unit_price"`
+	Quantity    int32   `json:"quantity"`
+}
+
+type OrderResponse struct {
+	ID          string               `json:"id"`
+	UserID      string               `json:"user_id"`
+	Status      string               `json:"status"`
+	TotalAmount float64              `json:"total_amount"`
+	Items       []*OrderItemResponse `json:"items"`
+	CreatedAt   string               `json:"created_at"`
+	UpdatedAt   string               `json:"updated_at"`
+}
+
+// numericToFloat converts pgtype.Numeric to float64
+func numericToFloat(n pgtype.Numeric) float64 {
+	if !n.Valid {
+		return 0
+	}
+	val, err := n.Float64Value()
+	if err != nil || !val.Valid {
+		return 0
+	}
+	return val.Float64
+}
+
+// convertOrderToResponse converts service.OrderWithItems to OrderResponse
+func convertOrderToResponse(order *service.OrderWithItems) *OrderResponse {
+	items := make([]*OrderItemResponse, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = convertOrderItemToResponse(item)
+	}
+
+	return &OrderResponse{
+		ID:          order.Order.ID.String(),
+		UserID:      order.Order.UserID,
+		Status:      order.Order.Status,
+		TotalAmount: numericToFloat(order.Order.TotalAmount),
+		Items:       items,
+		CreatedAt:   order.Order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   order.Order.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// convertOrderItemToResponse converts db.OrderItem to OrderItemResponse
+func convertOrderItemToResponse(item *db.OrderItem) *OrderItemResponse {
+	return &OrderItemResponse{
+		ID:          item.ID.String(),
+		ProductID:   item.ProductID.String(),
+		ProductName: item.ProductName,
+		UnitPrice:   numericToFloat(item.UnitPrice),
+		Quantity:    item.Quantity,
+	}
+}
+
+func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	var req CreateOrderRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		return err
+	}
+
+	items := make([]service.OrderItemInput, len(req.Items))
+	for i, item := range req.Items {
+		productID, err := uuid.Parse(item.ProductID)
+		if err != nil {
+			return apperrors.NewBadRequestError("Invalid product ID format")
+		}
+		items[i] = service.OrderItemInput{ProductID: productID, Quantity: item.Quantity}
+	}
+
+	ctx := r.Context()
+	order, err := h.orderService.CreateOrder(ctx, userID, items)
+	if err != nil {
+		return err
+	}
+
+	response := convertOrderToResponse(order)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(response)
+}
+
+func (h *OrderHandler) GetOrders(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	limit, err := parseQueryInt(r, "limit", h.maxPageSize)
+	if err != nil || limit <= 0 || limit > h.maxPageSize {
+		return apperrors.NewBadRequestError(fmt.Sprintf("limit must be between 1 and %d", h.maxPageSize))
+	}
+
+	offset, err := parseQueryInt(r, "offset", 0)
+	if err != nil || offset < 0 {
+		return apperrors.NewBadRequestError("offset must be non-negative")
+	}
+
+	ctx := r.Context()
+	orders, err := h.orderService.ListOrders(ctx, userID, int32(limit), int32(offset))
+	if err != nil {
+		return err
+	}
+
+	responses := make([]*OrderResponse, len(orders))
+	for i, order := range orders {
+		responses[i] = convertOrderToResponse(order)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(responses)
+}
+
+func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid order ID format")
+	}
+
+	ctx := r.Context()
+	order, err := h.orderService.GetOrder(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	response := convertOrderToResponse(order)
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// PayOrder handles POST /orders/{id}/pay
+func (h *OrderHandler) PayOrder(w http.ResponseWriter, r *http.Request) error {
+	return h.transitionOrder(w, r, domain.OrderStatusPaid)
+}
+
+// ShipOrder handles POST /orders/{id}/ship
+func (h *OrderHandler) ShipOrder(w http.ResponseWriter, r *http.Request) error {
+	return h.transitionOrder(w, r, domain.OrderStatusShipped)
+}
+
+// CancelOrder handles POST /orders/{id}/cancel
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) error {
+	return h.transitionOrder(w, r, domain.OrderStatusCancelled)
+}
+
+// transitionOrder moves the order addressed by the {id} path value to next
+func (h *OrderHandler) transitionOrder(w http.ResponseWriter, r *http.Request, next domain.OrderStatus) error {
+	userID, err := requireUserID(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid order ID format")
+	}
+
+	ctx := r.Context()
+	order, err := h.orderService.TransitionOrder(ctx, userID, id, next)
+	if err != nil {
+		return err
+	}
+
+	response := convertOrderToResponse(order)
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// parseQueryInt reads an integer query parameter, falling back to defaultValue when absent
+func parseQueryInt(r *http.Request, key string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"pkg/common_handler"
+	"pkg/router"
+)
+
+// Handlers are the handlers served under the versioned API routes
+type Handlers struct {
+	Errors *common_handler.ErrorHandler
+	Order  *OrderHandler
+}
+
+// RegisterRoutes registers every order-service API route on v1.
+// Each route must also be documented in NewOpenAPISpec.
+func RegisterRoutes(v1 *router.VersionedRoutes, h Handlers) {
+	v1.HandleFunc("GET orders", h.Errors.Handle(h.Order.GetOrders))
+	v1.HandleFunc("POST orders", h.Errors.Handle(h.Order.CreateOrder))
+	v1.HandleFunc("GET orders/{id}", h.Errors.Handle(h.Order.GetOrder))
+	v1.HandleFunc("POST orders/{id}/pay", h.Errors.Handle(h.Order.PayOrder))
+	v1.HandleFunc("POST orders/{id}/ship", h.Errors.Handle(h.Order.ShipOrder))
+	v1.HandleFunc("POST orders/{id}/cancel", h.Errors.Handle(h.Order.CancelOrder))
+}
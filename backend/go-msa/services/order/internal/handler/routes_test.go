@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"pkg/logger"
+	"pkg/router"
+)
+
+func TestRoutesAreDocumented(t *testing.T) {
+	log := logger.NewWithWriters("order-service", "error", "json", io.Discard, io.Discard)
+
+	versions := router.NewVersions(http.NewServeMux(), "order")
+	v1 := versions.Mount("v1", router.VersionPolicy{})
+	RegisterRoutes(v1, Handlers{
+		Errors: NewErrorHandler(log),
+		Order:  NewOrderHandler(nil, 1),
+	})
+
+	spec := NewOpenAPISpec("order-service", v1)
+	if missing := spec.Undocumented(versions.Patterns()); len(missing) > 0 {
+		t.Errorf("routes missing from the OpenAPI document: %v", missing)
+	}
+}
@@ -0,0 +1,15 @@
+package service
+
+import "errors"
+
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrEmptyOrder         = errors.New("order must contain at least one item")
+	ErrTooManyItems       = errors.New("order contains too many items")
+	ErrInvalidQuantity    = errors.New("quantity must be positive")
+	ErrProductNotFound    = errors.New("product not found")
+	ErrInsufficientStock  = errors.New("insufficient stock")
+	ErrInvalidTransition  = errors.New("invalid order status transition")
+	ErrConcurrentUpdate   = errors.New("order was modified concurrently")
+	ErrReservationExpired = errors.New("order stock reservation has expired")
+)
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"order-service/internal/client"
+	"order-service/internal/db"
+	"order-service/internal/domain"
+)
+
+// OrderItemInput is a requested line item before its product has been looked up
+type OrderItemInput struct {
+	ProductID uuid.UUID
+	Quantity  int32
+}
+
+// OrderWithItems is an order together with its line items
+type OrderWithItems struct {
+	Order *db.Order
+	Items []*db.OrderItem
+}
+
+type OrderService struct {
+	conn           *db.Connection
+	products       client.ProductClient
+	maxItems       int
+	reservationTTL time.Duration
+}
+
+func NewOrderService(conn *db.Connection, products client.ProductClient, maxItems int, reservationTTL time.Duration) *OrderService {
+	return &OrderService{
+		conn:           conn,
+		products:       products,
+		maxItems:       maxItems,
+		reservationTTL: reservationTTL,
+	}
+}
+
+// CreateOrder reserves stock in business-service and stores a pending order
+// whose line items snapshot the current product name and price.
+// The reservations are released again if the order cannot be stored.
+func (s *OrderService) CreateOrder(ctx context.Context, userID string, items []OrderItemInput) (*OrderWithItems, error) {
+	if len(items) == 0 {
+		return nil, ErrEmptyOrder
+	}
+	if len(items) > s.maxItems {
+		return nil, ErrTooManyItems
+	}
+
+	// Stock is reserved for the total quantity requested per product
+	requested := make(map[uuid.UUID]int32, len(items))
+	productIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		if _, ok := requested[item.ProductID]; !ok {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		requested[item.ProductID] += item.Quantity
+	}
+
+	products, err := s.products.GetProducts(ctx, userID, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	for _, productID := range productIDs {
+		if _, ok := products[productID]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+		}
+	}
+
+	reservations, err := s.reserveStock(ctx, userID, productIDs, requested)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalCents int64
+	for _, item := range items {
+		totalCents += toCents(products[item.ProductID].Price) * int64(item.Quantity)
+	}
+
+	result := &OrderWithItems{}
+	err = s.conn.ExecTx(ctx, func(q *db.Queries) error {
+		order, err := q.CreateOrder(ctx, db.CreateOrderParams{
+			UserID:      userID,
+			TotalAmount: centsToNumeric(totalCents),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+		result.Order = order
+
+		for _, item := range items {
+			product := products[item.ProductID]
+			orderItem, err := q.CreateOrderItem(ctx, db.CreateOrderItemParams{
+				OrderID:       order.ID,
+				ProductID:     item.ProductID,
+				ProductName:   product.Name,
+				UnitPrice:     centsToNumeric(toCents(product.Price)),
+				Quantity:      item.Quantity,
+				ReservationID: pgtype.UUID{Bytes: reservations[item.ProductID], Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create order item: %w", err)
+			}
+			result.Items = append(result.Items, orderItem)
+		}
+		return nil
+	})
+	if err != nil {
+		s.releaseReservations(ctx, userID, slices.Collect(maps.Values(reservations)))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetOrder retrieves one of userID's orders with its items
+func (s *OrderService) GetOrder(ctx context.Context, userID string, id uuid.UUID) (*OrderWithItems, error) {
+	order, err := s.getOwnedOrder(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withItems(ctx, order)
+}
+
+// ListOrders retrieves a page of userID's orders, newest first
+func (s *OrderService) ListOrders(ctx context.Context, userID string, limit, offset int32) ([]*OrderWithItems, error) {
+	orders, err := s.conn.Queries.ListOrdersByUser(ctx, db.ListOrdersByUserParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	results := make([]*OrderWithItems, len(orders))
+	for i, order := range orders {
+		results[i], err = s.withItems(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// TransitionOrder moves one of userID's orders to next if the lifecycle allows it.
+// Paying confirms the order's stock reservations and cancelling a pending order releases them.
+// Stock confirmed for a paid order is not returned when it is cancelled.
+func (s *OrderService) TransitionOrder(ctx context.Context, userID string, id uuid.UUID, next domain.OrderStatus) (*OrderWithItems, error) {
+	order, err := s.getOwnedOrder(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	current := domain.OrderStatus(order.Status)
+	if !current.CanTransitionTo(next) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, current, next)
+	}
+
+	items, err := s.conn.Queries.ListOrderItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order items: %w", err)
+	}
+	reservations := reservationIDs(items)
+
+	// An order whose stock is no longer held must not be paid
+	if next == domain.OrderStatusPaid {
+		if err := s.confirmReservations(ctx, userID, reservations); err != nil {
+			return nil, err
+		}
+	}
+
+	// The status guard makes the update a compare-and-set against the state checked above
+	updated, err := s.conn.Queries.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
+		Status:     string(next),
+		ID:         id,
+		FromStatus: string(current),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrConcurrentUpdate
+		}
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	if next == domain.OrderStatusCancelled {
+		s.releaseReservations(ctx, userID, reservations)
+	}
+
+	return &OrderWithItems{Order: updated, Items: items}, nil
+}
+
+// reserveStock reserves the requested quantity of each product and returns the reservation IDs by product.
+// If any product cannot be reserved, the reservations already made are released.
+func (s *OrderService) reserveStock(ctx context.Context, userID string, productIDs []uuid.UUID, requested map[uuid.UUID]int32) (map[uuid.UUID]uuid.UUID, error) {
+	reservations := make(map[uuid.UUID]uuid.UUID, len(productIDs))
+	for _, productID := range productIDs {
+		reservation, err := s.products.ReserveStock(ctx, userID, productID, requested[productID], s.reservationTTL)
+		if err != nil {
+			s.releaseReservations(ctx, userID, slices.Collect(maps.Values(reservations)))
+			switch {
+			case errors.Is(err, client.ErrInsufficientStock):
+				return nil, fmt.Errorf("%w: %s", ErrInsufficientStock, productID)
+			case errors.Is(err, client.ErrProductNotFound):
+				return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+			}
+			return nil, fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		reservations[productID] = reservation.ID
+	}
+
+	return reservations, nil
+}
+
+// confirmReservations confirms each reservation.
+// Reservations confirmed by an earlier attempt are accepted, so a failed payment can be retried.
+func (s *OrderService) confirmReservations(ctx context.Context, userID string, ids []uuid.UUID) error {
+	for _, id := range ids {
+		_, err := s.products.ConfirmReservation(ctx, userID, id)
+		if errors.Is(err, client.ErrReservationNotPending) {
+			var reservation *client.Reservation
+			reservation, err = s.products.GetReservation(ctx, userID, id)
+			if err == nil && reservation.Status != client.ReservationStatusConfirmed {
+				return fmt.Errorf("%w: %s is %s", ErrReservationExpired, id, reservation.Status)
+			}
+		}
+		if errors.Is(err, client.ErrReservationNotFound) {
+			return fmt.Errorf("%w: %s", ErrReservationExpired, id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to confirm reservation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// releaseReservations releases each reservation, ignoring failures:
+// reservations already settled stay as they are and the rest expire on their own.
+// Releases run even if ctx was cancelled, so an abandoned request does not keep stock held until then.
+func (s *OrderService) releaseReservations(ctx context.Context, userID string, ids []uuid.UUID) {
+	ctx = context.WithoutCancel(ctx)
+	for _, id := range ids {
+		_, _ = s.products.ReleaseReservation(ctx, userID, id)
+	}
+}
+
+// getOwnedOrder loads an order, hiding orders that belong to other users
+func (s *OrderService) getOwnedOrder(ctx context.Context, userID string, id uuid.UUID) (*db.Order, error) {
+	order, err := s.conn.Queries.GetOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, ErrOrderNotFound
+	}
+
+	return order, nil
+}
+
+// withItems attaches an order's line items
+func (s *OrderService) withItems(ctx context.Context, order *db.Order) (*OrderWithItems, error) {
+	items, err := s.conn.Queries.ListOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order items: %w", err)
+	}
+
+	return &OrderWithItems{Order: order, Items: items}, nil
+}
+
+// reservationIDs returns the distinct reservations of an order's items
+func reservationIDs(items []*db.OrderItem) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, item := range items {
+		if !item.ReservationID.Valid {
+			continue
+		}
+		id := uuid.UUID(item.ReservationID.Bytes)
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// toCents converts a decimal price to integer cents so totals are computed exactly
+func toCents(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
+
+// centsToNumeric converts integer cents to a two-decimal pgtype.Numeric
+func centsToNumeric(cents int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(cents), Exp: -2, Valid: true}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"order-service/internal/client"
+)
+
+// fakeProductClient serves products and reservations from memory and records released reservations
+type fakeProductClient struct {
+	products     map[uuid.UUID]*client.Product
+	stock        map[uuid.UUID]int32
+	reservations map[uuid.UUID]*client.Reservation
+	released     []uuid.UUID
+}
+
+func newFakeProductClient() *fakeProductClient {
+	return &fakeProductClient{
+		products:     map[uuid.UUID]*client.Product{},
+		stock:        map[uuid.UUID]int32{},
+		reservations: map[uuid.UUID]*client.Reservation{},
+	}
+}
+
+func (c *fakeProductClient) addProduct(stock int32) uuid.UUID {
+	id := uuid.New()
+	c.products[id] = &client.Product{ID: id.String(), Name: "Widget", Price: 10, Stock: stock}
+	c.stock[id] = stock
+	return id
+}
+
+func (c *fakeProductClient) GetProducts(_ context.Context, _ string, ids []uuid.UUID) (map[uuid.UUID]*client.Product, error) {
+	products := map[uuid.UUID]*client.Product{}
+	for _, id := range ids {
+		if product, ok := c.products[id]; ok {
+			products[id] = product
+		}
+	}
+	return products, nil
+}
+
+func (c *fakeProductClient) ReserveStock(_ context.Context, _ string, productID uuid.UUID, quantity int32, _ time.Duration) (*client.Reservation, error) {
+	if c.stock[productID] < quantity {
+		return nil, client.ErrInsufficientStock
+	}
+	c.stock[productID] -= quantity
+	reservation := &client.Reservation{ID: uuid.New(), ProductID: productID, Quantity: quantity, Status: "pending"}
+	c.reservations[reservation.ID] = reservation
+	return reservation, nil
+}
+
+func (c *fakeProductClient) GetReservation(_ context.Context, _ string, id uuid.UUID) (*client.Reservation, error) {
+	reservation, ok := c.reservations[id]
+	if !ok {
+		return nil, client.ErrReservationNotFound
+	}
+	return reservation, nil
+}
+
+func (c *fakeProductClient) ConfirmReservation(_ context.Context, _ string, id uuid.UUID) (*client.Reservation, error) {
+	return c.settle(id, client.ReservationStatusConfirmed)
+}
+
+func (c *fakeProductClient) ReleaseReservation(_ context.Context, _ string, id uuid.UUID) (*client.Reservation, error) {
+	c.released = append(c.released, id)
+	reservation, err := c.settle(id, "released")
+	if err == nil {
+		c.stock[reservation.ProductID] += reservation.Quantity
+	}
+	return reservation, err
+}
+
+func (c *fakeProductClient) settle(id uuid.UUID, status string) (*client.Reservation, error) {
+	reservation, ok := c.reservations[id]
+	if !ok {
+		return nil, client.ErrReservationNotFound
+	}
+	if reservation.Status != "pending" {
+		return nil, client.ErrReservationNotPending
+	}
+	reservation.Status = status
+	return reservation, nil
+}
+
+func (c *fakeProductClient) Close() error { return nil }
+
+func TestCreateOrderRejectsUnavailableProducts(t *testing.T) {
+	products := newFakeProductClient()
+	// The database is never reached, so the connection is nil
+	s := NewOrderService(nil, products, 10, time.Minute)
+	ctx := context.Background()
+	available := products.addProduct(5)
+	scarce := products.addProduct(1)
+
+	// Quantities of the same product are reserved together
+	items := []OrderItemInput{
+		{ProductID: available, Quantity: 2},
+		{ProductID: scarce, Quantity: 1},
+		{ProductID: scarce, Quantity: 1},
+	}
+	if _, err := s.CreateOrder(ctx, "user-1", items); !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("CreateOrder() error = %v, want ErrInsufficientStock", err)
+	}
+	if len(products.released) != 1 || products.stock[available] != 5 {
+		t.Errorf("released %v, stock %d: the earlier reservation must be released", products.released, products.stock[available])
+	}
+
+	reserved := len(products.reservations)
+	missing := []OrderItemInput{{ProductID: available, Quantity: 1}, {ProductID: uuid.New(), Quantity: 1}}
+	if _, err := s.CreateOrder(ctx, "user-1", missing); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("CreateOrder() error = %v, want ErrProductNotFound", err)
+	}
+	if len(products.reservations) != reserved {
+		t.Error("stock was reserved for an order with a missing product")
+	}
+}
+
+func TestConfirmReservations(t *testing.T) {
+	products := newFakeProductClient()
+	s := NewOrderService(nil, products, 10, time.Minute)
+	ctx := context.Background()
+	productID := products.addProduct(10)
+
+	reserve := func() uuid.UUID {
+		t.Helper()
+		reservation, err := products.ReserveStock(ctx, "user-1", productID, 1, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reservation.ID
+	}
+
+	// A retried payment finds the first reservation already confirmed
+	confirmed, pending := reserve(), reserve()
+	if _, err := products.ConfirmReservation(ctx, "user-1", confirmed); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.confirmReservations(ctx, "user-1", []uuid.UUID{confirmed, pending}); err != nil {
+		t.Errorf("confirmReservations() error = %v", err)
+	}
+
+	released := reserve()
+	if _, err := products.ReleaseReservation(ctx, "user-1", released); err != nil {
+		t.Fatal(err)
+	}
+	for name, id := range map[string]uuid.UUID{"released": released, "missing": uuid.New()} {
+		if err := s.confirmReservations(ctx, "user-1", []uuid.UUID{id}); !errors.Is(err, ErrReservationExpired) {
+			t.Errorf("confirm %s: error = %v, want ErrReservationExpired", name, err)
+		}
+	}
+}